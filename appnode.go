@@ -81,7 +81,8 @@ type AppOpts struct {
 	OptConfigViper *viper.Viper
 	OptSpewcfg     *spew.ConfigState
 	OptReconf      bool
-	OptTheme       Themer // Optional theme for UI styling
+	OptTheme       Themer    // Optional theme for UI styling
+	OptEventBus    *EventBus // Optional event bus shared by the models
 }
 
 // AppOption is used to set options on the app model.
@@ -138,6 +139,13 @@ func WithTheme(theme Themer) AppOption {
 	}
 }
 
+// WithEventBus sets the event bus shared by the entire application tree.
+func WithEventBus(bus *EventBus) AppOption {
+	return func(m *DefaultAppModel) {
+		m.OptEventBus = bus
+	}
+}
+
 // AppView is the default implementation of the AppModel interface.
 func (m DefaultAppModel) AppView(quitting bool, err error) string {
 	if quitting {
@@ -5,8 +5,10 @@
 package bubbletree
 
 import (
+	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -43,8 +45,35 @@ type DefaultBranchModel struct {
 	// interface implementation.
 	DefaultCommonModel
 
-	// The <ModelID, *Model> map of all registered descendant models.
-	Models *sync.Map
+	// The ordered registry of all linked descendant models. It must be
+	// created with NewRegistry before linking models.
+	Models *Registry
+
+	// The maximum time to wait for descendants to finish during shutdown,
+	// DefaultShutdownDeadline when zero.
+	ShutdownDeadline time.Duration
+
+	// Whether the children panicking in Update() get disabled, so that the
+	// rest of the tree keeps running. See PanicError.
+	DisableOnPanic bool
+
+	// Whether the model is an error boundary, catching the recoverable
+	// errors of its subtree. See ErrorBoundary.
+	CatchErrors bool
+
+	// The recoverable errors caught as an error boundary, by child model ID.
+	failures map[string]ErrMsg
+
+	// The children IDs still running during the shutdown sequence.
+	pending []string
+
+	// The children IDs being unmounted, waiting for their shutdown sequence
+	// to complete.
+	unmounting []string
+
+	// Closed when the shutdown sequence completes, releasing the deadline
+	// timer.
+	shutdownDone chan struct{}
 }
 
 // Update is the default implementation of the BranchModel interface. It is the
@@ -55,6 +84,7 @@ type DefaultBranchModel struct {
 // an embeded DefaultBranchModel instead of completely overwriting the method.
 func (m DefaultBranchModel) Update(msg tea.Msg) (BranchModel, tea.Cmd) {
 	var cmds []tea.Cmd
+	m.scopeLogger()
 
 	switch msg := msg.(type) {
 	// When disabled requested, accept and mark this model as disabled.
@@ -71,6 +101,23 @@ func (m DefaultBranchModel) Update(msg tea.Msg) (BranchModel, tea.Cmd) {
 			}
 		}
 
+	// When a property is requested, set it on recipients and release the
+	// exclusive ones everywhere else.
+	case SetPropertyMsg:
+		m.setProperty(msg)
+
+	// When a property is added, set it.
+	case AddPropertyMsg:
+		if msg.IsRecipient(m.GetModelID()) {
+			m.updateProperty(msg, msg.Property, true)
+		}
+
+	// When a property removal is requested, unset it.
+	case UnsetPropertyMsg:
+		if msg.IsRecipient(m.GetModelID()) {
+			m.updateProperty(msg, msg.Property, false)
+		}
+
 	// When focus requested, accept and mark this model as focused.
 	case SetFocusMsg:
 		if msg.IsRecipient(m.GetModelID()) {
@@ -85,96 +132,222 @@ func (m DefaultBranchModel) Update(msg tea.Msg) (BranchModel, tea.Cmd) {
 			}
 		}
 
-	// ShuttingDownMsg means that the application is terminating: cleanup and inactivate.
+	// ShuttingDownMsg means that the application is terminating: cleanup,
+	// shut down all descendants and inactivate.
 	case ShutDownMsg:
-		if msg.IsRecipient(m.GetModelID()) && !m.IsShuttingDown() {
-			m.State = ShuttingDownState
-			m.LogStateChange(msg)
-
-			m.CancelContext()
-			cmds = append(cmds, ModelFinishedCmd(m.GetModelID()))
-			m.LogAction(msg, "Requesting model finished")
+		if msg.IsRecipient(m.GetModelID()) && !m.IsShuttingDown() && !m.IsFinished() {
+			cmds = append(cmds, m.startShutdown(msg))
 		}
 
-	// ModelFinishedMsg marks the end-of-life for the model instance.
+	// ModelFinishedMsg marks the end-of-life for the model instance, or for
+	// one of its children being waited for.
 	case ModelFinishedMsg:
 		if msg.IsRecipient(m.GetModelID()) && !m.IsFinished() {
-			m.State = FinishedState
-			m.LogStateChange(msg)
+			m.SetState(FinishedState, msg)
+			break
+		}
+		if m.isUnmounting(msg.ModelID) {
+			cmds = append(cmds, m.unmounted(msg))
+		}
+		if m.IsShuttingDown() {
+			cmds = append(cmds, m.childFinished(msg))
+		}
+
+	// Link a new child model at runtime.
+	case MountMsg:
+		if msg.IsRecipient(m.GetModelID()) && m.IsActive() {
+			cmds = append(cmds, m.mount(msg))
+		}
+
+	// Shut down and remove a child model at runtime.
+	case UnmountMsg:
+		if _, ok := m.Models.Get(msg.ModelID); ok {
+			cmds = append(cmds, m.unmount(msg))
+		}
+
+	// When a new theme is requested, use it from now on.
+	case SetThemeMsg:
+		if msg.IsRecipient(m.GetModelID()) {
+			m.Theme = msg.Theme
+			m.LogNotice(msg, "Theme changed")
+		}
+
+	// A recoverable error from the subtree, or from the model itself.
+	case ErrMsg:
+		if msg.Severity != RecoverableSeverity {
+			break
+		}
+		if msg.SourceModelID == m.GetModelID() {
+			m.SetState(ErroredState, msg)
+		}
+		if msg.BoundaryID == m.GetModelID() {
+			m.catchError(msg)
+		}
+
+	// A failed model retries, clear its caught error.
+	case RetryMsg:
+		if msg.IsRecipient(m.GetModelID()) && m.IsErrored() {
+			m.SetState(ActiveState, msg)
+		}
+		m.clearFailure(msg.ModelID)
+
+	// Retry the failed children on request.
+	case tea.KeyMsg:
+		if len(m.failures) > 0 && Matches(msg, RetryKey) {
+			cmds = append(cmds, m.retryFailures(msg))
+		}
+
+	// The descendants didn't all finish in time, finish anyway.
+	case shutdownDeadlineMsg:
+		if msg.ModelID == m.GetModelID() && m.IsShuttingDown() && len(m.pending) > 0 {
+			cmds = append(cmds, m.shutdownExpired(msg))
 		}
 	}
 
 	return m, tea.Batch(cmds...)
 }
 
+// InitNodeModels runs the Init method of all child models, in registry
+// order, and returns their batched commands.
+func (m DefaultBranchModel) InitNodeModels() tea.Cmd {
+	var cmds []tea.Cmd
+	m.RangeModels(func(model CommonModel) bool {
+		cmds = append(cmds, model.Init())
+		return true
+	})
+	return tea.Batch(cmds...)
+}
+
 // UpdateNodeModels is the default implementation of the BranchModel interface.
+// Messages implementing the Addressed interface are only passed down to the
+// children on the way to their destination, other messages are broadcast to
+// all children, except for console input only passed down to the child on
+// the focus path. Children disabled after panicking only get the messages
+// that may enable them again or shut them down, see DisableOnPanic. Children
+// are updated according to the registry UpdateMode, and their commands are
+// batched in registry order either way.
 func (m DefaultBranchModel) UpdateNodeModels(msg tea.Msg) tea.Cmd {
 	var (
-		cmds  []tea.Cmd
-		wg    sync.WaitGroup
-		cchan = make(chan tea.Cmd)
+		models = m.Models.Models()
+		cmds   = make([]tea.Cmd, len(models))
+		wg     sync.WaitGroup
 	)
 
-	m.Models.Range(func(key, value any) bool {
+	for i, model := range models {
+		if isInput(msg) && !m.onFocusPath(model) {
+			continue
+		}
+		if m.Models.panicked(model.GetModelID()) && !reachesPanicked(msg) {
+			continue
+		}
+		routed, ok := routeTo(model, msg)
+		if !ok {
+			continue
+		}
+
+		if m.Models.UpdateMode() == SequentialUpdate {
+			cmds[i] = m.UpdateNodeModel(model, routed)
+			continue
+		}
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
 
-			cchan <- m.UpdateNodeModel(value.(CommonModel), msg)
+			cmds[i] = m.UpdateNodeModel(model, routed)
 		}()
-		return true
-	})
-
-	// Wait for all goroutines, then close all channels while the main function,
-	// just below, collects data.
-	go func() {
-		wg.Wait()
-		close(cchan)
-	}()
-
-	for cmd := range cchan {
-		cmds = append(cmds, cmd)
 	}
+	wg.Wait()
 
 	return tea.Batch(cmds...)
 }
 
 // UpdateNodeModel runs the Update() method on a specified model with the
-// passed in message. The descendant returned tea.Cmd is relayed to the caller.
-func (m DefaultBranchModel) UpdateNodeModel(model CommonModel, msg tea.Msg) tea.Cmd {
-	var cmd tea.Cmd
+// passed in message. The descendant returned tea.Cmd is relayed to the caller,
+// along with its state hooks commands and a StateChangedMsg when its state
+// changed. A panic in the descendant Update() is recovered and reported as
+// an ErrMsg carrying a PanicError, the descendant is left unchanged.
+func (m DefaultBranchModel) UpdateNodeModel(model CommonModel, msg tea.Msg) (cmd tea.Cmd) {
+	defer func() {
+		if v := recover(); v != nil {
+			cmd = m.childPanicked(model, msg, v)
+		}
+	}()
+
+	updated, cmd := updateModel(model, msg)
+	m.Models.Store(updated)
+	return tea.Batch(cmd, stateChanged(m.GetModelID(), model, updated, msg))
+}
+
+// updateModel runs the Update() method of a branch or a leaf model.
+func updateModel(model CommonModel, msg tea.Msg) (CommonModel, tea.Cmd) {
 	if bModel, ok := model.(BranchModel); ok {
-		bModel, cmd = bModel.Update(msg)
-		m.Models.Store(bModel.GetModelID(), model)
-		return cmd
+		return bModel.Update(msg)
 	} else if lModel, ok := model.(LeafModel); ok {
-		lModel, cmd = lModel.Update(msg)
-		m.Models.Store(lModel.GetModelID(), lModel)
-		return cmd
-	} else {
-		panic("current model doesn't implement a branch or a leaf model")
+		return lModel.Update(msg)
 	}
+	panic("current model doesn't implement a branch or a leaf model")
 }
 
 // LinkNewModel takes a new descendant model and updates the model ID saved
-// by the model for later reference in addition to adding that new model to a
-// map of descendant models.
+// by the model for later reference in addition to adding that new model to
+// the registry of descendant models. The descendant context is cancelled
+// along with the model's one.
 func (m DefaultBranchModel) LinkNewModel(model CommonModel, modelID *string) {
 	*modelID = model.GetModelID()
-	m.Models.Store(model.GetModelID(), model)
+	m.Models.Store(model)
+	m.link(model)
+}
+
+// link makes the cancellation of the branch model context cascade to the
+// linked child model context, with the same cause, and scopes the logger of
+// pointer models. The cascade is stopped when the child is deleted from the
+// registry, e.g., once unmounted.
+func (m DefaultBranchModel) link(model CommonModel) {
+	if s, ok := model.(interface{ scopeLogger() }); ok {
+		s.scopeLogger()
+	}
+	if stop := cascadeContext(m.Ctx, model); stop != nil {
+		m.Models.setStop(model.GetModelID(), stop)
+	}
+}
+
+// cascadeContext makes the cancellation of the parent context cascade to the
+// model context, with the same cause. It returns the function stopping the
+// cascade, nil when the model has no context to cancel.
+func cascadeContext(parent context.Context, model CommonModel) (stop func() bool) {
+	child, ok := model.(interface{ CancelContextCause(cause error) })
+	if !ok || parent == nil || contextOf(model) == nil {
+		return nil
+	}
+	return context.AfterFunc(parent, func() {
+		child.CancelContextCause(context.Cause(parent))
+	})
+}
+
+// RangeModels calls fn sequentially for each linked descendant model, in
+// registry order. If fn returns false, the iteration stops.
+func (m DefaultBranchModel) RangeModels(fn func(model CommonModel) bool) {
+	m.Models.Range(fn)
+}
+
+// registry returns the registry of the linked descendant models.
+func (m DefaultBranchModel) registry() *Registry {
+	return m.Models
+}
+
+// Lookup returns the linked descendant model identified by modelID and
+// whether it was found.
+func (m DefaultBranchModel) Lookup(modelID string) (CommonModel, bool) {
+	return m.Models.Get(modelID)
 }
 
 // MustGetModel returns a model identified by the parameter modelID from the
-// linked model sync.Map. The function will panic if the model cannot be found
-// or if the found object doesn't implement the 'CommonModel' interface.
+// linked model registry. The function will panic if the model cannot be
+// found. Prefer Lookup when the model may legitimately be missing.
 func (m DefaultBranchModel) MustGetModel(modelID string) CommonModel {
-	if model, ok := m.Models.Load(modelID); !ok {
-		panic(fmt.Sprintf("cannot load '%s' model from map", modelID))
-	} else {
-		if model, ok := any(model).(CommonModel); !ok {
-			panic(fmt.Sprintf("loaded '%s' model doesn't implement CommonModel", modelID))
-		} else {
-			return model
-		}
+	model, ok := m.Models.Get(modelID)
+	if !ok {
+		panic(fmt.Sprintf("cannot load '%s' model from registry", modelID))
 	}
+	return model
 }
@@ -0,0 +1,95 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletreetest
+
+import (
+	"strings"
+
+	"github.com/yhcote/bubbletree"
+)
+
+// AssertState fails the test if the model identified by id isn't in the
+// expected state.
+func (h *Harness) AssertState(id string, want bubbletree.State) {
+	h.tb.Helper()
+	if got := h.MustModel(id).GetState(); got != want {
+		h.tb.Errorf("model %q state = %v, want %v", id, got, want)
+	}
+}
+
+// AssertActive fails the test if the model identified by id isn't in
+// ActiveState.
+func (h *Harness) AssertActive(id string) {
+	h.tb.Helper()
+	h.AssertState(id, bubbletree.ActiveState)
+}
+
+// AssertFinished fails the test if the model identified by id isn't in
+// FinishedState.
+func (h *Harness) AssertFinished(id string) {
+	h.tb.Helper()
+	h.AssertState(id, bubbletree.FinishedState)
+}
+
+// AssertProperties fails the test if the model identified by id doesn't
+// have all of the expected properties set.
+func (h *Harness) AssertProperties(id string, want bubbletree.Properties) {
+	h.tb.Helper()
+	if got := h.MustModel(id).GetProperties(); got&want != want {
+		h.tb.Errorf("model %q properties = %v, want %v set", id, got, want)
+	}
+}
+
+// AssertFocused fails the test if the model identified by id doesn't have
+// the Focused property set.
+func (h *Harness) AssertFocused(id string) {
+	h.tb.Helper()
+	if !h.MustModel(id).IsFocused() {
+		h.tb.Errorf("model %q is not focused", id)
+	}
+}
+
+// AssertNotFocused fails the test if the model identified by id has the
+// Focused property set.
+func (h *Harness) AssertNotFocused(id string) {
+	h.tb.Helper()
+	if h.MustModel(id).IsFocused() {
+		h.tb.Errorf("model %q is focused", id)
+	}
+}
+
+// AssertDisabled fails the test if the model identified by id doesn't have
+// the Disabled property set.
+func (h *Harness) AssertDisabled(id string) {
+	h.tb.Helper()
+	if !h.MustModel(id).IsDisabled() {
+		h.tb.Errorf("model %q is not disabled", id)
+	}
+}
+
+// AssertNoError fails the test if the root model recorded an error.
+func (h *Harness) AssertNoError() {
+	h.tb.Helper()
+	if err := h.LastError(); err != nil {
+		h.tb.Errorf("unexpected root model error: %v", err)
+	}
+}
+
+// AssertQuitting fails the test if the program wouldn't have exited.
+func (h *Harness) AssertQuitting() {
+	h.tb.Helper()
+	if !h.Quitting() {
+		h.tb.Errorf("program is not quitting")
+	}
+}
+
+// AssertViewContains fails the test if the ANSI-stripped root view doesn't
+// contain substr.
+func (h *Harness) AssertViewContains(substr string) {
+	h.tb.Helper()
+	if view := h.PlainView(); !strings.Contains(view, substr) {
+		h.tb.Errorf("view doesn't contain %q:\n%s", substr, view)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletreetest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// diffOp is a single line operation of a line-based diff.
+type diffOp int
+
+const (
+	opEqual diffOp = iota
+	opDelete
+	opInsert
+)
+
+// diffLine is one line of a computed diff, carrying the line numbers in both
+// the wanted (golden) and got (rendered) outputs.
+type diffLine struct {
+	op        diffOp
+	text      string
+	want, got int
+}
+
+// diffLines computes the line differences between want and got using the
+// longest common subsequence. Views are a few hundred lines at most, the
+// quadratic table is fine here.
+func diffLines(want, got []string) []diffLine {
+	n, m := len(want), len(got)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if want[i] == got[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var lines []diffLine
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && want[i] == got[j]:
+			lines = append(lines, diffLine{op: opEqual, text: want[i], want: i + 1, got: j + 1})
+			i++
+			j++
+		case j < m && (i == n || lcs[i][j+1] >= lcs[i+1][j]):
+			lines = append(lines, diffLine{op: opInsert, text: got[j], got: j + 1})
+			j++
+		default:
+			lines = append(lines, diffLine{op: opDelete, text: want[i], want: i + 1})
+			i++
+		}
+	}
+	return lines
+}
+
+// sideBySide renders the changed lines between want and got in two columns.
+// Within a run of changes, deleted and inserted lines are paired up on the
+// same row so that a modified line reads as 'want | got'. Unchanged lines
+// are elided.
+func sideBySide(want, got string) string {
+	lines := diffLines(strings.Split(want, "\n"), strings.Split(got, "\n"))
+
+	colWidth := len("want")
+	for _, l := range lines {
+		if l.op != opEqual {
+			colWidth = max(colWidth, ansi.StringWidth(l.text))
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%5s %s │ %5s %s\n", "line", pad("want", colWidth), "line", "got")
+
+	elided := false
+	for k := 0; k < len(lines); {
+		if lines[k].op == opEqual {
+			if !elided {
+				b.WriteString("  ...\n")
+				elided = true
+			}
+			k++
+			continue
+		}
+		elided = false
+
+		// Collect the run of changes and pair deletions with insertions.
+		var dels, inss []diffLine
+		for ; k < len(lines) && lines[k].op != opEqual; k++ {
+			if lines[k].op == opDelete {
+				dels = append(dels, lines[k])
+			} else {
+				inss = append(inss, lines[k])
+			}
+		}
+		for r := range max(len(dels), len(inss)) {
+			left, right := "", ""
+			leftNo, rightNo := "", ""
+			if r < len(dels) {
+				left, leftNo = dels[r].text, fmt.Sprint(dels[r].want)
+			}
+			if r < len(inss) {
+				right, rightNo = inss[r].text, fmt.Sprint(inss[r].got)
+			}
+			fmt.Fprintf(&b, "%5s %s │ %5s %s\n", leftNo, pad(left, colWidth), rightNo, right)
+		}
+	}
+	return b.String()
+}
+
+// pad right-pads s with spaces up to the display width w.
+func pad(s string, w int) string {
+	if sw := ansi.StringWidth(s); sw < w {
+		return s + strings.Repeat(" ", w-sw)
+	}
+	return s
+}
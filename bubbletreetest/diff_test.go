@@ -0,0 +1,107 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletreetest
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name      string
+		want, got string
+		diff      []diffLine
+	}{
+		{
+			name: "equal",
+			want: "a\nb",
+			got:  "a\nb",
+			diff: []diffLine{{opEqual, "a", 1, 1}, {opEqual, "b", 2, 2}},
+		},
+		{
+			name: "inserted",
+			want: "a\nc",
+			got:  "a\nb\nc",
+			diff: []diffLine{{opEqual, "a", 1, 1}, {opInsert, "b", 0, 2}, {opEqual, "c", 2, 3}},
+		},
+		{
+			name: "deleted",
+			want: "a\nb\nc",
+			got:  "a\nc",
+			diff: []diffLine{{opEqual, "a", 1, 1}, {opDelete, "b", 2, 0}, {opEqual, "c", 3, 2}},
+		},
+		{
+			name: "modified",
+			want: "a\nb",
+			got:  "a\nB",
+			diff: []diffLine{{opEqual, "a", 1, 1}, {opInsert, "B", 0, 2}, {opDelete, "b", 2, 0}},
+		},
+		{
+			name: "empty want",
+			want: "",
+			got:  "a",
+			diff: []diffLine{{opInsert, "a", 0, 1}, {opDelete, "", 1, 0}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := diffLines(strings.Split(tt.want, "\n"), strings.Split(tt.got, "\n"))
+			if !slices.Equal(diff, tt.diff) {
+				t.Errorf("diff = %v, want %v", diff, tt.diff)
+			}
+		})
+	}
+}
+
+func TestSideBySide(t *testing.T) {
+	tests := []struct {
+		name      string
+		want, got string
+		out       []string
+	}{
+		{
+			name: "modified",
+			want: "a\nb\nc",
+			got:  "a\nB\nc",
+			out: []string{
+				" line want │  line got",
+				"  ...",
+				"    2 b    │     2 B",
+				"  ...",
+			},
+		},
+		{
+			name: "uneven run",
+			want: "a\nb",
+			got:  "x\ny\nz\nb",
+			out: []string{
+				" line want │  line got",
+				"    1 a    │     1 x",
+				"           │     2 y",
+				"           │     3 z",
+				"  ...",
+			},
+		},
+		{
+			name: "wide lines",
+			want: "a long line",
+			got:  "",
+			out: []string{
+				" line want        │  line got",
+				"    1 a long line │     1 ",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := strings.Join(tt.out, "\n") + "\n"
+			if got := sideBySide(tt.want, tt.got); got != want {
+				t.Errorf("side by side diff:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletreetest
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/yhcote/bubbletree"
+)
+
+// update is set on the 'go test' command line to regenerate golden files
+// instead of comparing against them: go test ./... -bubbletreetest.update.
+// The flag is namespaced so that test packages can define their own -update
+// flag.
+var update = flag.Bool("bubbletreetest.update", false, "regenerate bubbletreetest golden files")
+
+const (
+	// goldenDir is where golden files are stored, relative to the package
+	// directory of the running test.
+	goldenDir = "testdata"
+
+	// defaultThemeName is used in golden file names when no theme name is
+	// specified.
+	defaultThemeName = "default"
+)
+
+// Snapshot identifies a rendered output that is compared against golden
+// files. Two golden files are kept per snapshot: one stripped of ANSI
+// sequences, easy to review, and one with the raw ANSI output, catching
+// styling regressions. Note that the raw output depends on the lipgloss
+// color profile, tests should force one with lipgloss.SetColorProfile().
+type Snapshot struct {
+	// Name of the snapshot, may contain '/' to group snapshots in
+	// sub-directories, e.g., "tabs/first-active".
+	Name string
+
+	// The view window size the output was rendered with.
+	Width  int
+	Height int
+
+	// Name of the theme the output was rendered with.
+	Theme string
+}
+
+// path returns the golden file path for the snapshot. The raw flag selects
+// the raw ANSI version of the golden file.
+func (s Snapshot) path(raw bool) string {
+	theme := s.Theme
+	if theme == "" {
+		theme = defaultThemeName
+	}
+	ext := ".golden"
+	if raw {
+		ext = ".ansi.golden"
+	}
+	return filepath.Join(goldenDir, filepath.FromSlash(s.Name)+
+		fmt.Sprintf("-%dx%d-%s%s", s.Width, s.Height, theme, ext))
+}
+
+// SizedRenderer is implemented by components rendered in a given window
+// size, such as Tabs or Winbar.
+type SizedRenderer interface {
+	Render(width, height int) string
+}
+
+// Renderer is implemented by components that size themselves, such as Table
+// or Card.
+type Renderer interface {
+	Render() string
+}
+
+// AssertGolden compares got against the snapshot golden files, failing the
+// test with a side-by-side diff of the changed lines on mismatch. When the
+// -bubbletreetest.update flag is set, the golden files are (re)written instead.
+func AssertGolden(tb testing.TB, snap Snapshot, got string) {
+	tb.Helper()
+	assertGoldenFile(tb, snap.path(false), ansi.Strip(got), false)
+	assertGoldenFile(tb, snap.path(true), got, true)
+}
+
+// AssertModelGolden renders the model view in the snapshot window size and
+// compares it against the snapshot golden files.
+func AssertModelGolden(tb testing.TB, snap Snapshot, model bubbletree.CommonModel) {
+	tb.Helper()
+	AssertGolden(tb, snap, model.View(snap.Width, snap.Height))
+}
+
+// AssertSizedRenderGolden renders the component in the snapshot window size
+// and compares it against the snapshot golden files.
+func AssertSizedRenderGolden(tb testing.TB, snap Snapshot, r SizedRenderer) {
+	tb.Helper()
+	AssertGolden(tb, snap, r.Render(snap.Width, snap.Height))
+}
+
+// AssertRenderGolden renders the component and compares it against the
+// snapshot golden files. The snapshot size is only used to name the files.
+func AssertRenderGolden(tb testing.TB, snap Snapshot, r Renderer) {
+	tb.Helper()
+	AssertGolden(tb, snap, r.Render())
+}
+
+// AssertGolden compares the current root view against the named snapshot
+// golden files, using the harness terminal size.
+func (h *Harness) AssertGolden(name, theme string) {
+	h.tb.Helper()
+	AssertGolden(h.tb, Snapshot{Name: name, Width: h.width, Height: h.height, Theme: theme}, h.View())
+}
+
+// assertGoldenFile compares got with the content of the golden file at path,
+// or writes it when updating. Raw ANSI outputs are quoted line by line in the
+// failure diff so that escape sequences don't garble the test output.
+func assertGoldenFile(tb testing.TB, path, got string, raw bool) {
+	tb.Helper()
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			tb.Fatalf("creating golden file directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			tb.Fatalf("writing golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		tb.Errorf("golden file %s doesn't exist, run the test with -bubbletreetest.update to create it", path)
+		return
+	} else if err != nil {
+		tb.Fatalf("reading golden file: %v", err)
+	}
+	if string(want) == got {
+		return
+	}
+
+	wantView, gotView := string(want), got
+	if raw {
+		wantView, gotView = quoteLines(wantView), quoteLines(gotView)
+	}
+	tb.Errorf("output doesn't match golden file %s (run with -bubbletreetest.update to regenerate):\n%s",
+		path, sideBySide(wantView, gotView))
+}
+
+// quoteLines quotes each line of s as a Go string literal.
+func quoteLines(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		lines[i] = strconv.Quote(l)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,378 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+// Package bubbletreetest provides a headless harness to drive a bubbletree
+// model tree from unit tests, without a terminal or a running bubble tea
+// program.
+package bubbletreetest
+
+import (
+	"go/token"
+	"reflect"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/yhcote/bubbletree"
+)
+
+const (
+	// defaultWidth and defaultHeight are the terminal dimensions sent in the
+	// initial tea.WindowSizeMsg, like bubble tea does at program startup.
+	defaultWidth  = 80
+	defaultHeight = 24
+
+	// defaultCmdTimeout is the maximum time a single tea.Cmd may block before
+	// the harness moves on to the next one, leaving it pending.
+	defaultCmdTimeout = 250 * time.Millisecond
+
+	// defaultMaxSteps is the maximum number of messages processed by a single
+	// Send call before the tree is considered to be looping.
+	defaultMaxSteps = 10000
+)
+
+// Harness wraps a DefaultRootModel and runs its Init/Update/View cycle
+// synchronously. Messages injected with Send are processed in order, and all
+// resulting tea.Cmd (including batches and sequences) are executed in order
+// until the model tree stops producing new messages and no command is left
+// pending.
+type Harness struct {
+	tb   testing.TB
+	root bubbletree.DefaultRootModel
+
+	width      int
+	height     int
+	cmdTimeout time.Duration
+	maxSteps   int
+
+	// Whether a tea.QuitMsg was processed.
+	quit bool
+
+	// The commands still running past the per-command timeout, in start
+	// order.
+	pending []pendingCmd
+}
+
+// Option is used to set options on the harness at creation.
+type Option func(*Harness)
+
+// WithSize sets the terminal dimensions sent in the initial
+// tea.WindowSizeMsg.
+func WithSize(width, height int) Option {
+	return func(h *Harness) {
+		h.width = width
+		h.height = height
+	}
+}
+
+// WithCmdTimeout sets the maximum time a single tea.Cmd may block before the
+// harness moves on to the next one. The messages of the commands left pending
+// are processed once the tree is otherwise quiescent, in start order. It
+// should be shorter than the timers the tested models rely on, like shutdown
+// deadlines.
+func WithCmdTimeout(timeout time.Duration) Option {
+	return func(h *Harness) {
+		h.cmdTimeout = timeout
+	}
+}
+
+// WithMaxSteps sets the maximum number of messages processed by a single
+// Send call before the test fails.
+func WithMaxSteps(steps int) Option {
+	return func(h *Harness) {
+		h.maxSteps = steps
+	}
+}
+
+// New creates a harness around the app model, runs the tree Init() commands
+// and sends the initial tea.WindowSizeMsg, the same way bubble tea would when
+// starting a program.
+func New(tb testing.TB, app bubbletree.AppModel, opts ...Option) *Harness {
+	tb.Helper()
+
+	h := &Harness{
+		tb:         tb,
+		root:       toRoot(tb, bubbletree.New(app)),
+		width:      defaultWidth,
+		height:     defaultHeight,
+		cmdTimeout: defaultCmdTimeout,
+		maxSteps:   defaultMaxSteps,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	tb.Cleanup(func() {
+		if len(h.pending) > 0 {
+			tb.Logf("%d tea.Cmd still running at the end of the test", len(h.pending))
+		}
+	})
+
+	h.run(h.exec([]tea.Cmd{h.root.Init()}, false))
+	h.Send(tea.WindowSizeMsg{Width: h.width, Height: h.height})
+	return h
+}
+
+// Send injects messages into the root model and processes them, along with
+// every message produced by the returned commands, until the tree is
+// quiescent.
+func (h *Harness) Send(msgs ...tea.Msg) {
+	h.tb.Helper()
+	h.run(msgs)
+}
+
+// Resize sends a tea.WindowSizeMsg with the new terminal dimensions.
+func (h *Harness) Resize(width, height int) {
+	h.tb.Helper()
+	h.width = width
+	h.height = height
+	h.Send(tea.WindowSizeMsg{Width: width, Height: height})
+}
+
+// Key sends a key press of the specified type, e.g., tea.KeyEsc.
+func (h *Harness) Key(key tea.KeyType) {
+	h.tb.Helper()
+	h.Send(tea.KeyMsg{Type: key})
+}
+
+// Type sends one key press message per rune of the string.
+func (h *Harness) Type(s string) {
+	h.tb.Helper()
+	for _, r := range s {
+		h.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+// ShutDown sends a ShutDownMsg for the listed model instances.
+func (h *Harness) ShutDown(ids ...string) {
+	h.tb.Helper()
+	h.Send(bubbletree.ShutDownMsg{ModelIDs: ids})
+}
+
+// View returns the current root model view, as bubble tea would print it.
+func (h *Harness) View() string {
+	return h.root.View()
+}
+
+// PlainView returns the current root model view stripped of ANSI sequences.
+func (h *Harness) PlainView() string {
+	return ansi.Strip(h.root.View())
+}
+
+// Root returns the wrapped root model.
+func (h *Harness) Root() bubbletree.DefaultRootModel {
+	return h.root
+}
+
+// App returns the core application model.
+func (h *Harness) App() bubbletree.AppModel {
+	return h.root.CoreApp
+}
+
+// LastError returns the last error recorded by the root model.
+func (h *Harness) LastError() error {
+	return h.root.LastError()
+}
+
+// Quitting returns whether the program would have exited: the root model is
+// quitting or a tea.QuitMsg was processed.
+func (h *Harness) Quitting() bool {
+	return h.quit || h.root.Quitting
+}
+
+// Width returns the last terminal width sent to the tree.
+func (h *Harness) Width() int {
+	return h.width
+}
+
+// Height returns the last terminal height sent to the tree.
+func (h *Harness) Height() int {
+	return h.height
+}
+
+// FocusPath returns the model IDs leading from the core application model
+// down to the focused model.
+func (h *Harness) FocusPath() []string {
+	return h.root.Focus.Path()
+}
+
+// Model returns the model instance identified by id, searching the whole
+// tree from the core application model down.
+func (h *Harness) Model(id string) (bubbletree.CommonModel, bool) {
+	model, _, ok := bubbletree.Find(h.root.CoreApp, id)
+	return model, ok
+}
+
+// MustModel returns the model instance identified by id, failing the test if
+// it cannot be found.
+func (h *Harness) MustModel(id string) bubbletree.CommonModel {
+	h.tb.Helper()
+	model, ok := h.Model(id)
+	if !ok {
+		h.tb.Fatalf("model %q not found in tree", id)
+	}
+	return model
+}
+
+// run processes the queued messages, and the messages they generate, until
+// none are left or the program quits. The pending commands are then waited
+// for in start order, so that the messages are always processed in the same
+// order. When the oldest one is still running after the per-command timeout,
+// its messages are left to a later Send.
+func (h *Harness) run(queue []tea.Msg) {
+	h.tb.Helper()
+
+	for steps := 0; ; steps++ {
+		if h.Quitting() {
+			return
+		}
+		if steps >= h.maxSteps {
+			h.tb.Fatalf("model tree not quiescent after %d messages", h.maxSteps)
+		}
+		if len(queue) == 0 {
+			if len(h.pending) == 0 {
+				return
+			}
+			msgs, ok := h.resume()
+			if !ok {
+				return
+			}
+			queue = msgs
+			continue
+		}
+
+		msg := queue[0]
+		queue = queue[1:]
+
+		if _, ok := msg.(tea.QuitMsg); ok {
+			h.quit = true
+			return
+		}
+		if msg == nil || isInternal(msg) {
+			continue
+		}
+
+		model, cmd := h.root.Update(msg)
+		h.root = toRoot(h.tb, model)
+		queue = append(queue, h.exec([]tea.Cmd{cmd}, false)...)
+	}
+}
+
+// pendingCmd is a command still running past the per-command timeout, along
+// with the commands following it in a sequence.
+type pendingCmd struct {
+	result <-chan tea.Msg
+	rest   []tea.Cmd
+}
+
+// exec runs the commands one after the other and returns their messages in
+// order. Batches and sequences are flattened in order. A command still
+// running after the per-command timeout is left pending, along with the rest
+// of the commands when they form a sequence.
+func (h *Harness) exec(cmds []tea.Cmd, sequence bool) []tea.Msg {
+	var msgs []tea.Msg
+	for i, cmd := range cmds {
+		if cmd == nil {
+			continue
+		}
+
+		result := make(chan tea.Msg, 1)
+		go func() { result <- cmd() }()
+
+		msg, ok := h.wait(result)
+		if ok {
+			msgs = append(msgs, h.flatten(msg)...)
+			continue
+		}
+		if !sequence {
+			h.pending = append(h.pending, pendingCmd{result: result})
+			continue
+		}
+		h.pending = append(h.pending, pendingCmd{result: result, rest: cmds[i+1:]})
+		break
+	}
+	return msgs
+}
+
+// resume waits for the oldest pending command, and returns its messages along
+// with the ones of the rest of its sequence. It reports false when the
+// command is still running after the per-command timeout.
+func (h *Harness) resume() ([]tea.Msg, bool) {
+	p := h.pending[0]
+	msg, ok := h.wait(p.result)
+	if !ok {
+		return nil, false
+	}
+	h.pending = h.pending[1:]
+	return append(h.flatten(msg), h.exec(p.rest, true)...), true
+}
+
+// wait waits up to the per-command timeout for the result of a command.
+func (h *Harness) wait(result <-chan tea.Msg) (tea.Msg, bool) {
+	timer := time.NewTimer(h.cmdTimeout)
+	defer timer.Stop()
+
+	select {
+	case msg := <-result:
+		return msg, true
+	case <-timer.C:
+		return nil, false
+	}
+}
+
+// flatten returns the messages of a command result, running the commands of
+// batches and sequences.
+func (h *Harness) flatten(msg tea.Msg) []tea.Msg {
+	switch msg := msg.(type) {
+	case nil:
+		return nil
+	case tea.BatchMsg:
+		return h.exec(msg, false)
+	default:
+		if cmds, ok := asSequence(msg); ok {
+			return h.exec(cmds, true)
+		}
+		return []tea.Msg{msg}
+	}
+}
+
+// cmdType is the reflected type of tea.Cmd.
+var cmdType = reflect.TypeFor[tea.Cmd]()
+
+// asSequence returns the commands of the unexported bubble tea message
+// produced by tea.Sequence.
+func asSequence(msg tea.Msg) ([]tea.Cmd, bool) {
+	v := reflect.ValueOf(msg)
+	if v.Kind() != reflect.Slice || v.Type().Elem() != cmdType {
+		return nil, false
+	}
+	cmds := make([]tea.Cmd, v.Len())
+	for i := range cmds {
+		cmds[i] = v.Index(i).Interface().(tea.Cmd)
+	}
+	return cmds, true
+}
+
+// isInternal returns whether msg is an unexported bubble tea message meant
+// for the program itself (window title, cursor, screen modes...) rather than
+// for the model tree.
+func isInternal(msg tea.Msg) bool {
+	t := reflect.TypeOf(msg)
+	return t.PkgPath() == cmdType.PkgPath() && !token.IsExported(t.Name())
+}
+
+// toRoot converts the tea.Model returned by the root Update back to its
+// concrete type.
+func toRoot(tb testing.TB, model tea.Model) bubbletree.DefaultRootModel {
+	tb.Helper()
+	switch root := model.(type) {
+	case bubbletree.DefaultRootModel:
+		return root
+	case *bubbletree.DefaultRootModel:
+		return *root
+	default:
+		tb.Fatalf("root Update returned unexpected model type %T", model)
+		return bubbletree.DefaultRootModel{}
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletreetest
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/yhcote/bubbletree"
+)
+
+// startMsg makes the test app return the commands under test.
+type startMsg struct{}
+
+// gotMsg is returned by the commands under test.
+type gotMsg string
+
+// orderApp is a core application model recording the gotMsg and keys it
+// receives.
+type orderApp struct {
+	bubbletree.DefaultAppModel
+	got  *[]string
+	cmds func() tea.Cmd
+}
+
+func newOrderApp(cmds func() tea.Cmd) orderApp {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	return orderApp{
+		DefaultAppModel: bubbletree.DefaultAppModel{DefaultBranchModel: bubbletree.DefaultBranchModel{
+			DefaultCommonModel: bubbletree.DefaultCommonModel{
+				ID:     "app-1",
+				Logger: slog.New(slog.DiscardHandler),
+				Ctx:    ctx,
+				Cancel: cancel,
+				Tasks:  bubbletree.NewTaskRunner(),
+			},
+			Models: bubbletree.NewRegistry(),
+		}},
+		got:  new([]string),
+		cmds: cmds,
+	}
+}
+
+func (m orderApp) Update(msg tea.Msg) (bubbletree.BranchModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case startMsg:
+		return m, m.cmds()
+	case gotMsg:
+		*m.got = append(*m.got, string(msg))
+		return m, nil
+	case tea.KeyMsg:
+		*m.got = append(*m.got, msg.String())
+		return m, nil
+	}
+	branch, cmd := m.DefaultBranchModel.Update(msg)
+	m.DefaultBranchModel = branch.(bubbletree.DefaultBranchModel)
+	return m, cmd
+}
+
+func (m orderApp) AppView(bool, error) string {
+	return ""
+}
+
+// returns returns a command returning gotMsg(s) after the delay.
+func returns(s string, delay time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		time.Sleep(delay)
+		return gotMsg(s)
+	}
+}
+
+// gated returns a command returning gotMsg(s) once the gate is open, past the
+// harness per-command timeout.
+func gated(s string, gate <-chan struct{}) tea.Cmd {
+	return func() tea.Msg {
+		<-gate
+		return gotMsg(s)
+	}
+}
+
+// opening returns a command opening the gate and returning gotMsg(s).
+func opening(s string, gate chan<- struct{}) tea.Cmd {
+	return func() tea.Msg {
+		close(gate)
+		return gotMsg(s)
+	}
+}
+
+func TestHarnessOrder(t *testing.T) {
+	const timeout = 20 * time.Millisecond
+
+	tests := []struct {
+		name string
+		cmds func() tea.Cmd
+		want []string
+	}{
+		{
+			name: "batch",
+			cmds: func() tea.Cmd {
+				return tea.Batch(returns("a", timeout/4), returns("b", 0), returns("c", timeout/2))
+			},
+			want: []string{"a", "b", "c"},
+		},
+		{
+			name: "sequence",
+			cmds: func() tea.Cmd {
+				return tea.Sequence(returns("a", 0), tea.Batch(returns("b", 0), returns("c", 0)), returns("d", 0))
+			},
+			want: []string{"a", "b", "c", "d"},
+		},
+		{
+			name: "pending",
+			cmds: func() tea.Cmd {
+				gate := make(chan struct{})
+				return tea.Batch(gated("slow", gate), opening("fast", gate))
+			},
+			want: []string{"fast", "slow"},
+		},
+		{
+			name: "pending sequence",
+			cmds: func() tea.Cmd {
+				gate := make(chan struct{})
+				return tea.Batch(
+					tea.Sequence(returns("a", 0), gated("slow", gate), returns("b", 0)),
+					opening("fast", gate),
+				)
+			},
+			want: []string{"a", "fast", "slow", "b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for range 5 {
+				app := newOrderApp(tt.cmds)
+				h := New(t, app, WithCmdTimeout(timeout))
+				h.Send(startMsg{})
+				if !slices.Equal(*app.got, tt.want) {
+					t.Fatalf("messages = %v, want %v", *app.got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestHarnessStillRunning(t *testing.T) {
+	const timeout = 10 * time.Millisecond
+	release := make(chan struct{})
+	app := newOrderApp(func() tea.Cmd {
+		return func() tea.Msg {
+			<-release
+			return gotMsg("late")
+		}
+	})
+	h := New(t, app, WithCmdTimeout(timeout))
+
+	h.Send(startMsg{})
+	if len(*app.got) != 0 {
+		t.Fatalf("messages = %v, want none yet", *app.got)
+	}
+	close(release)
+	h.Send()
+	if !slices.Equal(*app.got, []string{"late"}) {
+		t.Errorf("messages = %v, want [late]", *app.got)
+	}
+}
+
+func TestHarnessInput(t *testing.T) {
+	app := newOrderApp(func() tea.Cmd { return tea.Quit })
+	h := New(t, app, WithSize(40, 10))
+	if h.Width() != 40 || h.Height() != 10 || h.Root().Width != 40 || h.Root().Height != 10 {
+		t.Errorf("size = %dx%d, root %dx%d, want 40x10", h.Width(), h.Height(), h.Root().Width, h.Root().Height)
+	}
+
+	h.Type("ab")
+	h.Key(tea.KeyEnter)
+	if want := []string{"a", "b", "enter"}; !slices.Equal(*app.got, want) {
+		t.Errorf("keys = %v, want %v", *app.got, want)
+	}
+
+	h.Resize(20, 5)
+	if h.Root().Width != 20 || h.Root().Height != 5 {
+		t.Errorf("root size = %dx%d, want 20x5", h.Root().Width, h.Root().Height)
+	}
+	if h.Quitting() {
+		t.Fatal("quitting before tea.Quit")
+	}
+	h.Send(startMsg{})
+	if !h.Quitting() {
+		t.Error("not quitting after tea.Quit")
+	}
+}
@@ -6,15 +6,19 @@ package bubbletree
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"log/slog"
 	"slices"
-	"strings"
 
 	"github.com/spf13/viper"
 
 	tea "github.com/charmbracelet/bubbletea"
-	charmlog "github.com/charmbracelet/log"
+)
+
+var (
+	// ErrShutDown is the cause recorded when a model context is cancelled by
+	// its shutdown sequence.
+	ErrShutDown = errors.New("model shut down")
 )
 
 // CommonModel is an interface defining the routine requirements for each
@@ -55,17 +59,34 @@ type DefaultCommonModel struct {
 	// The initial viper Viper passed along.
 	Viper *viper.Viper
 
-	// slog Logger to use throughout the model.
+	// slog Logger to use throughout the model. It is scoped with the model
+	// ModelID attribute when the model handles its first message in the
+	// default Update implementations, or when it is linked to a parent if it
+	// is a pointer, see ModelLogger.
 	Logger *slog.Logger
 
-	// The model context needed to notify long running goroutines.
+	// The scoped Logger, and the logger it was scoped from.
+	scoped, unscoped *slog.Logger
+
+	// The model context needed to notify long running goroutines. Once the
+	// model is linked to a parent, the context is cancelled along with the
+	// parent's one, with the same cause.
 	Ctx context.Context
 
-	// The cancel func associated with the above context.
-	Cancel context.CancelFunc
+	// The cancel func associated with the above context, created with
+	// context.WithCancelCause.
+	Cancel context.CancelCauseFunc
 
 	// Optional theme for UI styling. Can be nil if application doesn't use theming.
 	Theme Themer
+
+	// Optional event bus shared by the application models. Can be nil if
+	// application doesn't use events.
+	Bus *EventBus
+
+	// Optional runner of the model background tasks, see StartTask. Can be
+	// nil if the model doesn't run tasks.
+	Tasks *TaskRunner
 }
 
 // Init is the default implementation of the CommonModel interface. It sends
@@ -102,23 +123,47 @@ func (m DefaultCommonModel) GetViewHeader(w, h int) string {
 // GetViewFooter is the default implementation of the CommonModel interface.
 // It returns the view portion that should be displayed in the app's footer
 // section of the final composed UI window. This should be a short string or
-// a unicode icon.
+// a unicode icon. It is empty by default, ViewFooter then renders the model's
+// declared key bindings instead.
 func (m DefaultCommonModel) GetViewFooter(w, h int) string {
-	if m.IsActive() {
-		return "Application's footer notes / menus"
-	}
 	return ""
 }
 
 // CancelContext is the default implementation of the CommonModel interface.
-// It is used to explicitely cancel the model's instance context.
+// It is used to explicitely cancel the model's instance context, and so the
+// contexts of its whole subtree, with ErrShutDown as the cause.
 func (m DefaultCommonModel) CancelContext() {
-	if m.Cancel != nil {
-		m.Logger.Info("Cancelling model's context")
-		m.Cancel()
-	} else {
+	m.CancelContextCause(ErrShutDown)
+}
+
+// CancelContextCause cancels the model's instance context, and so the
+// contexts of its whole subtree, recording the cause. Cancelling an already
+// cancelled context is a no-op, the first cause is kept.
+func (m DefaultCommonModel) CancelContextCause(cause error) {
+	if m.Cancel == nil {
 		m.Logger.Warn("The model's cancel function is unexpectedly nil, context cannot be cancelled.")
+		return
+	}
+	if m.Ctx != nil && m.Ctx.Err() != nil {
+		m.ModelLogger().Debug("Model's context already cancelled", "Cause", context.Cause(m.Ctx))
+		return
 	}
+	m.ModelLogger().Info("Cancelling model's context", "Cause", cause)
+	m.Cancel(cause)
+}
+
+// modelContext returns the model's instance context.
+func (m DefaultCommonModel) modelContext() context.Context {
+	return m.Ctx
+}
+
+// contextOf returns the context of the models embedding DefaultCommonModel,
+// if set.
+func contextOf(model CommonModel) context.Context {
+	if c, ok := model.(interface{ modelContext() context.Context }); ok {
+		return c.modelContext()
+	}
+	return nil
 }
 
 // GetModelID is the default implementation of the CommonModel interface. It
@@ -131,10 +176,11 @@ func (m DefaultCommonModel) GetModelID() string {
 }
 
 // GetState is the default implementation of the CommonModel interface. It
-// returns the model's current state. For now, it informs whether the model
-// is doing work (ActiveState), whether it hasn't started yet (InactiveState),
-// that it is shutting down (ShuttingDownState) or terminated with all
-// resources freed (FinishedState).
+// returns the model's current state. It informs whether the model is doing
+// work (ActiveState), whether it hasn't started yet (InactiveState), is
+// paused (SuspendedState), failed (ErroredState), is shutting down
+// (ShuttingDownState) or terminated with all resources freed
+// (FinishedState).
 func (m DefaultCommonModel) GetState() State {
 	return m.State
 }
@@ -167,6 +213,18 @@ func (m DefaultCommonModel) IsFinished() bool {
 	return m.State == FinishedState
 }
 
+// IsSuspended returns whether the model's instance is currently in
+// "Suspended" state. Other models are checked with GetState.
+func (m DefaultCommonModel) IsSuspended() bool {
+	return m.State == SuspendedState
+}
+
+// IsErrored returns whether the model's instance is currently in "Errored"
+// state. Other models are checked with GetState.
+func (m DefaultCommonModel) IsErrored() bool {
+	return m.State == ErroredState
+}
+
 // GetProperties is the default implementation of the CommonModel interface.
 // It returns the model's instance current property set.
 func (m DefaultCommonModel) GetProperties() Properties {
@@ -198,138 +256,19 @@ func (m DefaultCommonModel) GetTheme() Themer {
 	return DefaultMinimalTheme()
 }
 
-// LogStateChange sends a standardized log entry after a model state change.
-func (m DefaultCommonModel) LogStateChange(msg any) {
-	handler, ok := m.Logger.Handler().(*charmlog.Logger)
-	if !ok {
-		panic("logger's handler concrete type is not *charmlog.Logger")
-	}
-	handler.Helper()
-	handler.Info("Model State Change",
-		"ModelID", m.GetModelID(),
-		"NewState", m.State,
-		"OnMsg", fmt.Sprintf("%T", msg))
-}
-
-// LogAction sends a standardized log entry after a new queued tea.Cmd.
-func (m DefaultCommonModel) LogAction(msg any, action string) {
-	handler, ok := m.Logger.Handler().(*charmlog.Logger)
-	if !ok {
-		panic("logger's handler concrete type is not *charmlog.Logger")
-	}
-	handler.Helper()
-	handler.Info("Model Action",
-		"ModelID", m.GetModelID(),
-		"Action", action,
-		"OnMsg", fmt.Sprintf("%T", msg))
-}
-
-// LogPropertyChange sends a standardized log entry after a model property
-// change.
-func (m DefaultCommonModel) LogPropertyChange(msg any, old, new Properties) {
-	handler, ok := m.Logger.Handler().(*charmlog.Logger)
-	if !ok {
-		panic("logger's handler concrete type is not *charmlog.Logger")
-	}
-	handler.Helper()
-	handler.Info("Model Property Change",
-		"ModelID", m.GetModelID(),
-		"Properties", fmt.Sprintf("%v -> %v", old, new),
-		"OnMsg", fmt.Sprintf("%T", msg))
-}
-
-// LogNotice sends a standardized log entry of a notification.
-func (m DefaultCommonModel) LogNotice(msg any, notice string) {
-	handler, ok := m.Logger.Handler().(*charmlog.Logger)
-	if !ok {
-		panic("logger's handler concrete type is not *charmlog.Logger")
-	}
-	handler.Helper()
-	handler.Info("Model Notification",
-		"ModelID", m.GetModelID(),
-		"Notice", notice,
-		"OnMsg", fmt.Sprintf("%T", msg))
-}
-
-// The possible bubble tree model states
-const (
-	InactiveState State = iota
-	ActiveState
-	ShuttingDownState
-	FinishedState
-)
-
-type State int
-
-func (s State) String() string {
-	switch s {
-	case InactiveState:
-		return "INACTIVE"
-	case ActiveState:
-		return "ACTIVE"
-	case ShuttingDownState:
-		return "SHUTTINGDOWN"
-	case FinishedState:
-		return "FINISHED"
-	default:
-		return "UNKNOWN"
+// GetLogger returns the model's logger if set, otherwise returns the slog
+// default logger.
+func (m DefaultCommonModel) GetLogger() *slog.Logger {
+	if m.Logger != nil {
+		return m.Logger
 	}
-}
-
-// The possible bubble tree model properties
-const (
-	Disabled Properties = 1 << iota
-	Focused
-)
-
-type Properties int
-
-func (p Properties) String() string {
-	var props []string
-	if p == 0 {
-		return "NONE"
-	}
-	if p&Disabled != 0 {
-		props = append(props, "DISABLED")
-	}
-	if p&Focused != 0 {
-		props = append(props, "FOCUSED")
-	}
-	return strings.Join(props, "|")
-}
-
-// SetDisabled sets the Disabled model property.
-func (p *Properties) SetDisabled() (old, new Properties) {
-	old = *p
-	*p |= Disabled
-	return old, *p
-}
-
-// UnsetDisabled unsets the Disabled model property.
-func (p *Properties) UnsetDisabled() (old, new Properties) {
-	old = *p
-	*p &= ^Disabled
-	return old, *p
-}
-
-// SetFocused sets the Focused model property.
-func (p *Properties) SetFocused() (old, new Properties) {
-	old = *p
-	*p |= Focused
-	return old, *p
-}
-
-// UnsetFocused unsets the Focused model property.
-func (p *Properties) UnsetFocused() (old, new Properties) {
-	old = *p
-	*p &= ^Focused
-	return old, *p
+	return slog.Default()
 }
 
 // Msg/Cmd's
 
 type (
-	// ShutDownMsg is a model-global message used to request that the list of
+	// ShutDownMsg is an addressed message used to request that the list of
 	// specified model instances enter their shutdown sequence. This allows time for
 	// graceful cleanup before tea.Quit get called at a later time or when a model
 	// instance self terminate.
@@ -340,18 +279,27 @@ type (
 	// freed.
 	ModelFinishedMsg struct{ ModelID string }
 
-	// SetFocusMsg is a model-global message sent to request a change of model
+	// SetFocusMsg is an addressed message sent to request a change of model
 	// instance focus. The message includes the Model ID that needs focus. Target
 	// models should set the Focused property on the model and act approprietely.
 	SetFocusMsg struct{ ModelID string }
 
-	// SetDisabledMsg is a model-global message sent to request that models instances
+	// SetDisabledMsg is an addressed message sent to request that models instances
 	// listed have the "Disabled" properties set.
 	SetDisabledMsg struct{ ModelIDs []string }
 
-	// ErrMsg is a model-global message sent when an error occured while running the
-	// model.
-	ErrMsg struct{ Err error }
+	// ErrMsg is a message sent when an error occured while running the model.
+	// Fatal errors, the default, make the root model quit. Recoverable errors
+	// are addressed to the nearest error boundary of the source model instead,
+	// see ErrorBoundary. Warnings are only logged.
+	ErrMsg struct {
+		Err           error
+		Severity      Severity
+		SourceModelID string
+
+		// The error boundary catching the error, filled in by the root model.
+		BoundaryID string
+	}
 )
 
 // IsRecipient returns whether the message is destined to the specified model
@@ -360,6 +308,12 @@ func (msg ShutDownMsg) IsRecipient(id string) bool {
 	return slices.Contains(msg.ModelIDs, id)
 }
 
+// MsgRoute implements the Addressed interface. The message is only forwarded
+// down the subtrees holding the listed model instances.
+func (msg ShutDownMsg) MsgRoute() Route {
+	return Route{ModelIDs: msg.ModelIDs}
+}
+
 // IsRecipient returns whether the message is destined to the specified model
 // instance.
 func (msg ModelFinishedMsg) IsRecipient(id string) bool {
@@ -372,12 +326,26 @@ func (msg SetFocusMsg) IsRecipient(id string) bool {
 	return msg.ModelID == id
 }
 
+// MsgRoute implements the Addressed interface. The message is forwarded down
+// the subtree holding the model instance to focus, and the subtrees holding
+// currently focused ones so that they can release the focus.
+func (msg SetFocusMsg) MsgRoute() Route {
+	return Route{ModelIDs: []string{msg.ModelID}, Release: Focused}
+}
+
 // IsRecipient returns whether the message is destined to the specified model
 // instance.
 func (msg SetDisabledMsg) IsRecipient(id string) bool {
 	return slices.Contains(msg.ModelIDs, id)
 }
 
+// MsgRoute implements the Addressed interface. The message is forwarded down
+// the subtrees holding the listed model instances, and the currently
+// disabled ones so that they can be re-enabled.
+func (msg SetDisabledMsg) MsgRoute() Route {
+	return Route{ModelIDs: msg.ModelIDs, Release: Disabled}
+}
+
 // ShutDownCmd returns a model-global message when the system is requested
 // to shut down. This is typically sent by the root model following an fatal
 // error or when the user wants to close the application. Model instances should
@@ -415,8 +383,17 @@ func SetDisabledCmd(ids []string) tea.Cmd {
 // ErrCmd returns a model-global message when an error occured.
 func ErrCmd(err error) tea.Cmd {
 	return func() tea.Msg {
-		return ErrMsg{err}
+		return ErrMsg{Err: err}
 	}
 }
 
 func (e ErrMsg) Error() string { return e.Err.Error() }
+
+// MsgRoute implements the Addressed interface. Errors caught by a boundary
+// go to the boundary and to the source model. Other errors are broadcast.
+func (e ErrMsg) MsgRoute() Route {
+	if e.BoundaryID == "" {
+		return Route{}
+	}
+	return Route{ModelIDs: []string{e.BoundaryID, e.SourceModelID}}
+}
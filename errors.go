@@ -0,0 +1,171 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Severity is how serious an error reported with an ErrMsg is.
+type Severity int
+
+// The possible error severities
+const (
+	// FatalSeverity errors make the root model quit the program.
+	FatalSeverity Severity = iota
+
+	// RecoverableSeverity errors put the source model in the Errored state.
+	// The nearest error boundary renders it in error until it is retried.
+	// Without a boundary, the error is fatal.
+	RecoverableSeverity
+
+	// WarningSeverity errors are logged by the root model, without changing
+	// the source model state.
+	WarningSeverity
+)
+
+func (s Severity) String() string {
+	switch s {
+	case FatalSeverity:
+		return "FATAL"
+	case RecoverableSeverity:
+		return "RECOVERABLE"
+	case WarningSeverity:
+		return "WARNING"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// RetryKey is the key binding used by error boundaries to retry their failed
+// children.
+var RetryKey = KeyBinding{Keys: []string{"ctrl+r"}, Desc: "retry"}
+
+// ErrorBoundary is implemented by branch models catching the recoverable
+// errors of their subtree, warnings are only logged by the root model.
+// DefaultBranchModel implements it, acting as a boundary when CatchErrors is
+// set.
+type ErrorBoundary interface {
+	IsErrorBoundary() bool
+}
+
+// IsErrorBoundary implements the ErrorBoundary interface.
+func (m DefaultBranchModel) IsErrorBoundary() bool {
+	return m.CatchErrors
+}
+
+// Failed returns the recoverable error caught for the child model, if it is
+// in error.
+func (m DefaultBranchModel) Failed(id string) (ErrMsg, bool) {
+	err, ok := m.failures[id]
+	return err, ok
+}
+
+// ViewNodeModel renders the child model in a w by h window, or its error
+// view when the boundary caught an error from its subtree. Panics in the
+// child View() are recovered and rendered as errors.
+func (m DefaultBranchModel) ViewNodeModel(model CommonModel, w, h int) string {
+	if err, ok := m.Failed(model.GetModelID()); ok {
+		return markRegion(model.GetModelID(), m.errorView(model.GetModelID(), err, w, h), w, h)
+	}
+	return safeView(model, w, h)
+}
+
+// catchError records a recoverable error from the subtree, against the child
+// model on the way to the source model.
+func (m *DefaultBranchModel) catchError(msg ErrMsg) {
+	m.RangeModels(func(child CommonModel) bool {
+		if child.GetModelID() != msg.SourceModelID && !isDescendant(child, msg.SourceModelID) {
+			return true
+		}
+		if m.failures == nil {
+			m.failures = make(map[string]ErrMsg)
+		}
+		m.failures[child.GetModelID()] = msg
+		m.ModelLogger().Warn("Error caught by boundary",
+			"Child", child.GetModelID(),
+			"Source", msg.SourceModelID,
+			"error", msg.Err)
+		return false
+	})
+}
+
+// retryFailures clears the caught errors and sends a RetryMsg to their
+// source models.
+func (m *DefaultBranchModel) retryFailures(msg tea.Msg) tea.Cmd {
+	var cmds []tea.Cmd
+	for id, err := range m.failures {
+		cmds = append(cmds, RetryCmd(err.SourceModelID))
+		delete(m.failures, id)
+	}
+	m.LogAction(msg, "Requesting retry of failed models")
+	return tea.Batch(cmds...)
+}
+
+// clearFailure clears the caught error of the source model being retried.
+func (m *DefaultBranchModel) clearFailure(source string) {
+	for id, err := range m.failures {
+		if err.SourceModelID == source {
+			delete(m.failures, id)
+		}
+	}
+}
+
+// errorView renders the error state of a failed child model.
+func (m DefaultBranchModel) errorView(id string, err ErrMsg, w, h int) string {
+	theme := m.GetTheme()
+	s := theme.RenderErrorText(fmt.Sprintf("%s failed: %v", id, err.Err)) + "\n\n" +
+		theme.RenderSecondaryText(fmt.Sprintf("Press %s to %s", RetryKey.Label(), RetryKey.Desc))
+	return theme.GetBaseStyle().Width(w).Height(h).MaxWidth(w).MaxHeight(h).Render(s)
+}
+
+// errorBoundary returns the ID of the nearest error boundary above the
+// model identified by id, or an empty string if there is none.
+func errorBoundary(app CommonModel, id string) string {
+	path := findPath(app, id)
+	for i := len(path) - 2; i >= 0; i-- {
+		model, _ := findModel(app, path[i])
+		if boundary, ok := model.(ErrorBoundary); ok && boundary.IsErrorBoundary() {
+			return path[i]
+		}
+	}
+	return ""
+}
+
+// Msg/Cmd's
+
+// RetryMsg is an addressed message requesting a model in the Errored state
+// to retry the work that failed. The default models handling moves it back to
+// the Active state, and its error boundary clears the caught error.
+type RetryMsg struct{ ModelID string }
+
+// MsgRoute implements the Addressed interface.
+func (msg RetryMsg) MsgRoute() Route {
+	return Route{ModelIDs: []string{msg.ModelID}}
+}
+
+// IsRecipient returns whether the message is destined to the specified model
+// instance.
+func (msg RetryMsg) IsRecipient(id string) bool {
+	return msg.ModelID == id
+}
+
+// ModelErrCmd returns an error message of the specified severity, sent by
+// the source model instance.
+func ModelErrCmd(id string, severity Severity, err error) tea.Cmd {
+	return func() tea.Msg {
+		return ErrMsg{Err: err, Severity: severity, SourceModelID: id}
+	}
+}
+
+// RetryCmd returns a message requesting the model instance to retry its
+// failed work.
+func RetryCmd(id string) tea.Cmd {
+	return func() tea.Msg {
+		return RetryMsg{ModelID: id}
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Topic is a named event bus topic, events published on it carry a payload
+// of type T. Topics are usually declared once as package variables shared by
+// publishers and subscribers, e.g.:
+//
+//	var ConfigReady = bubbletree.NewTopic[Config]("config.ready")
+type Topic[T any] struct {
+	name string
+}
+
+// NewTopic returns the topic identified by name.
+func NewTopic[T any](name string) Topic[T] {
+	return Topic[T]{name: name}
+}
+
+// Name returns the topic name.
+func (t Topic[T]) Name() string {
+	return t.name
+}
+
+// EventBus is an in-process publish/subscribe event bus between models.
+// Events published on a topic are delivered as Event messages to the models
+// subscribed to it only, wherever they are in the tree. An EventBus is
+// shared by pointer and is safe for concurrent use. A nil EventBus has no
+// subscribers and ignores subscriptions.
+type EventBus struct {
+	mu sync.Mutex
+
+	// The subscriptions of each topic, by topic name, in subscription order.
+	subs map[string][]subscription
+}
+
+// subscription is a model subscribed to a topic.
+type subscription struct {
+	id string
+
+	// Stops the automatic unsubscription on context cancellation.
+	stop func() bool
+}
+
+// NewEventBus returns a new EventBus without subscriptions.
+func NewEventBus() *EventBus {
+	return &EventBus{subs: make(map[string][]subscription)}
+}
+
+// Subscribe subscribes the model instance id to the topic, usually from its
+// Init(). The model is automatically unsubscribed when ctx, normally the
+// model context, is cancelled at shutdown. Subscribing twice is a no-op.
+func Subscribe[T any](bus *EventBus, topic Topic[T], id string, ctx context.Context) {
+	if bus == nil {
+		return
+	}
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+
+	if bus.subs == nil {
+		bus.subs = make(map[string][]subscription)
+	}
+	if slices.ContainsFunc(bus.subs[topic.name], func(s subscription) bool { return s.id == id }) {
+		return
+	}
+	sub := subscription{id: id, stop: func() bool { return false }}
+	if ctx != nil {
+		sub.stop = context.AfterFunc(ctx, func() { bus.unsubscribe(topic.name, id) })
+	}
+	bus.subs[topic.name] = append(bus.subs[topic.name], sub)
+}
+
+// Unsubscribe removes the model instance id subscription to the topic.
+func Unsubscribe[T any](bus *EventBus, topic Topic[T], id string) {
+	bus.unsubscribe(topic.name, id)
+}
+
+// unsubscribe removes the model instance id subscription to the named topic.
+func (bus *EventBus) unsubscribe(name, id string) {
+	if bus == nil {
+		return
+	}
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+
+	bus.subs[name] = slices.DeleteFunc(bus.subs[name], func(s subscription) bool {
+		if s.id == id {
+			s.stop()
+			return true
+		}
+		return false
+	})
+	if len(bus.subs[name]) == 0 {
+		delete(bus.subs, name)
+	}
+}
+
+// Subscribers returns the IDs of the models subscribed to the named topic.
+func (bus *EventBus) Subscribers(name string) []string {
+	if bus == nil {
+		return nil
+	}
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+
+	ids := make([]string, 0, len(bus.subs[name]))
+	for _, s := range bus.subs[name] {
+		ids = append(ids, s.id)
+	}
+	return ids
+}
+
+// Msg/Cmd's
+
+// Event is an addressed message delivering a payload published on a topic to
+// its subscribers. Subscribers match the events they expect by type, e.g.:
+//
+//	case bubbletree.Event[Config]:
+//		if msg.Topic == ConfigReady { ... }
+type Event[T any] struct {
+	Topic Topic[T]
+
+	// The publishing model instance ID.
+	Publisher string
+
+	Payload T
+
+	// The subscribers at publication time.
+	to []string
+}
+
+// MsgRoute implements the Addressed interface.
+func (msg Event[T]) MsgRoute() Route {
+	return Route{ModelIDs: msg.to}
+}
+
+// IsRecipient returns whether the message is destined to the specified model
+// instance.
+func (msg Event[T]) IsRecipient(id string) bool {
+	return slices.Contains(msg.to, id)
+}
+
+// Publish returns an Event delivering the payload to the topic subscribers.
+// Nothing is sent when the topic has no subscribers.
+func Publish[T any](bus *EventBus, topic Topic[T], publisher string, payload T) tea.Cmd {
+	return func() tea.Msg {
+		to := bus.Subscribers(topic.name)
+		if len(to) == 0 {
+			return nil
+		}
+		return Event[T]{Topic: topic, Publisher: publisher, Payload: payload, to: to}
+	}
+}
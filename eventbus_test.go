@@ -0,0 +1,45 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+var testTopic = NewTopic[string]("test")
+
+func TestEventBus(t *testing.T) {
+	tests := []struct {
+		name       string
+		bus        *EventBus
+		subscribed []string
+		published  []string
+	}{
+		{"nil", nil, nil, nil},
+		{"zero", &EventBus{}, []string{"pane-1", "pane-2"}, []string{"pane-2"}},
+		{"new", NewEventBus(), []string{"pane-1", "pane-2"}, []string{"pane-2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Subscribe(tt.bus, testTopic, "pane-1", nil)
+			Subscribe(tt.bus, testTopic, "pane-2", context.Background())
+			Subscribe(tt.bus, testTopic, "pane-1", nil)
+			if got := tt.bus.Subscribers(testTopic.Name()); !slices.Equal(got, tt.subscribed) {
+				t.Errorf("subscribers = %v, want %v", got, tt.subscribed)
+			}
+
+			Unsubscribe(tt.bus, testTopic, "pane-1")
+			var to []string
+			if e, ok := Publish(tt.bus, testTopic, "app-1", "payload")().(Event[string]); ok {
+				to = e.to
+			}
+			if !slices.Equal(to, tt.published) {
+				t.Errorf("published to %v, want %v", to, tt.published)
+			}
+		})
+	}
+}
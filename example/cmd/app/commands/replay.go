@@ -0,0 +1,64 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"example/internal/app"
+	"example/models/coreapp"
+
+	"github.com/spf13/cobra"
+	"github.com/yhcote/bubbletree"
+	"github.com/yhcote/bubbletree/logger"
+)
+
+var (
+	// replay command flags
+	replaySpeed    float64
+	replayHeadless bool
+)
+
+// replayCmd replays the messages recorded with the --record flag.
+var replayCmd = &cobra.Command{
+	Use:   "replay <file>",
+	Short: "Replay the messages recorded from a previous run",
+	Long: `Replay feeds the messages recorded with the --record flag to a fresh
+application, in the terminal or headless, to reproduce a program session. The
+same config file should be used as for the recorded run.`,
+	Args: cobra.ExactArgs(1),
+
+	PreRun: func(cmd *cobra.Command, args []string) {
+		app.ProgramName = cmd.Root().Name()
+	},
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger.Log().Info("Replaying", "program", app.ProgramName, "version", app.ProgramVersion, "file", args[0])
+
+		f, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("could not open record file: %w", err)
+		}
+		defer f.Close()
+
+		replayOptions := []bubbletree.ReplayOption{bubbletree.WithReplaySpeed(replaySpeed)}
+		if replayHeadless {
+			replayOptions = append(replayOptions, bubbletree.WithHeadless())
+		}
+		if err := coreapp.Replay(f, replayOptions, appOptions()...); err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(replayCmd)
+
+	replayCmd.Flags().Float64Var(&replaySpeed, "speed", 1, "replay speed factor, 0 replays without delays")
+	replayCmd.Flags().BoolVar(&replayHeadless, "headless", false, "replay without a terminal")
+}
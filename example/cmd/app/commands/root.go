@@ -29,6 +29,9 @@ var (
 	configForce bool
 	configFile  string
 	configViper *viper.Viper
+
+	// the file the program messages are recorded to, for the replay command
+	recordFile string
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -49,21 +52,30 @@ manual page.`,
 
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		logger.Log().Info("Starting", "program", app.ProgramName, "version", app.ProgramVersion)
-		fmt.Printf("Starting %v version %v\n - log file:\t\t%v\n - config file:\t\t%v\n\n",
+		fmt.Printf("Starting %v version %v\n - log file:\t\t%v\n - config file:\t\t%v\n",
 			app.ProgramName, app.ProgramVersion, logger.GetLoggerOutputName(), configViper.ConfigFileUsed())
 
-		// Initialize App's base (coreapp) model
-		options := []bubbletree.AppOption{
-			bubbletree.WithProgname(app.ProgramName),
-			bubbletree.WithProgver(app.ProgramVersion),
-			bubbletree.WithConfigViper(configViper),
-			bubbletree.WithReconfigure(configForce),
-			bubbletree.WithSpewConfigState(&spew.ConfigState{MaxDepth: 1}),
-			bubbletree.WithTheme(themes.Default()),
+		// Record the program messages when requested.
+		var rootOptions []bubbletree.RootOption
+		if recordFile != "" {
+			f, err := os.Create(recordFile)
+			if err != nil {
+				return fmt.Errorf("could not create record file: %w", err)
+			}
+			defer f.Close()
+			recorder := bubbletree.NewRecorder(f)
+			defer func() {
+				if err := recorder.Err(); err != nil {
+					logger.Log().Error("Recording stopped", "file", recordFile, "error", err)
+				}
+			}()
+			rootOptions = append(rootOptions, bubbletree.WithRecorder(recorder))
+			fmt.Printf(" - record file:\t\t%v\n", recordFile)
 		}
+		fmt.Println()
 
 		// Run the bubble tree program via the initialized coreapp.
-		err = coreapp.Run(options...)
+		err = coreapp.Run(rootOptions, appOptions()...)
 		if err != nil {
 			cmd.SilenceUsage = true
 			return err
@@ -76,6 +88,18 @@ manual page.`,
 	},
 }
 
+// appOptions returns the options initializing App's base (coreapp) model.
+func appOptions() []bubbletree.AppOption {
+	return []bubbletree.AppOption{
+		bubbletree.WithProgname(app.ProgramName),
+		bubbletree.WithProgver(app.ProgramVersion),
+		bubbletree.WithConfigViper(configViper),
+		bubbletree.WithReconfigure(configForce),
+		bubbletree.WithSpewConfigState(&spew.ConfigState{MaxDepth: 1}),
+		bubbletree.WithTheme(themes.Default()),
+	}
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
@@ -92,6 +116,7 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&configFile, "config",
 		filepath.Join(filepath.Join(os.Getenv("HOME"), ".config", progname), progname+".json"),
 		"config file (default is $HOME/.config/"+progname+"/"+progname+".json)")
+	rootCmd.Flags().StringVar(&recordFile, "record", "", "record the program messages to a file, see the replay command")
 }
 
 // initConfigViper reads in config file and ENV variables if set.
@@ -107,6 +132,12 @@ func initConfigViper() {
 
 	// If a config file is found, read it in.
 	readConfigFile(configViper)
+
+	// Set up the logger outputs from the config file settings.
+	if err := logger.ConfigureViper(configViper); err != nil {
+		logger.Log().Error("Could not configure the logger outputs", "error", err)
+		fmt.Fprintf(os.Stderr, "Could not configure the logger outputs, error=%v\n", err)
+	}
 	logger.Log().Info("Using config file", "file", configViper.ConfigFileUsed())
 	logger.Log().Debug("Config file", "data", spew.Sdump(configViper.AllSettings()))
 }
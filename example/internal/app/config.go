@@ -8,9 +8,16 @@ import (
 	"errors"
 
 	"github.com/spf13/viper"
+	"github.com/yhcote/bubbletree"
 	"github.com/yhcote/bubbletree/logger"
 )
 
+// Event bus topics
+var (
+	// ConfigSaved is published when a new configuration was saved to disk.
+	ConfigSaved = bubbletree.NewTopic[Config]("config.saved")
+)
+
 var (
 	ErrViperToLocalConfig = errors.New("could not translate viper to local app settings")
 )
@@ -33,4 +40,7 @@ func ViperToLocalConfig(vpr *viper.Viper) (config Config, err error) {
 // Config is the local viper unmarshaled application config
 type Config struct {
 	Placeholder string
+
+	// The logger outputs and levels settings, see logger.ConfigureViper.
+	Log logger.Config `mapstructure:"log" json:"log"`
 }
@@ -33,6 +33,13 @@ type (
 	ConfigCancelMsg struct{}
 )
 
+// Record the model messages, see bubbletree.Replay.
+func init() {
+	bubbletree.RegisterMsg[ConfigReadyMsg]("configurator.ConfigReadyMsg")
+	bubbletree.RegisterMsg[ConfigMissingMsg]("configurator.ConfigMissingMsg")
+	bubbletree.RegisterMsg[ConfigCancelMsg]("configurator.ConfigCancelMsg")
+}
+
 // GetConfigCmd is responsible for loading an existing application
 // configuration file, if available, or to create a new one otherwise. The
 // function calls 'isComplete()' returning whether a config file has all
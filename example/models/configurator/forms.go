@@ -87,9 +87,9 @@ func (m Model) updateForm(msg tea.Msg) (bubbletree.LeafModel, tea.Cmd) {
 	if m.form.State == huh.StateCompleted {
 		// We're done here, save form fields to viper configs and write down a new
 		// config version to disk.
-		config := app.Config{
-			Placeholder: inputPlaceholder,
-		}
+		// Keep the settings not part of the form, like logging, as loaded.
+		config, _ := app.ViperToLocalConfig(m.Viper)
+		config.Placeholder = inputPlaceholder
 
 		jsonConfig, err := json.MarshalIndent(config, "", "    ")
 		if err != nil {
@@ -99,9 +99,17 @@ func (m Model) updateForm(msg tea.Msg) (bubbletree.LeafModel, tea.Cmd) {
 			m.Logger.Debug("JSON Config", "config", string(jsonConfig))
 			if err = os.WriteFile(m.Viper.ConfigFileUsed(), jsonConfig, 0644); err != nil {
 				err = fmt.Errorf("while writing JSON config to file: %w", err)
-				cmds = append(cmds, bubbletree.ErrCmd(err))
+				cmds = append(cmds, bubbletree.ModelErrCmd(m.ID, bubbletree.RecoverableSeverity, err))
 			} else {
-				cmds = append(cmds, configReadyCmd(config))
+				cmds = append(cmds,
+					configReadyCmd(config),
+					bubbletree.Publish(m.Bus, app.ConfigSaved, m.ID, config),
+					bubbletree.NotifyCmd(bubbletree.Notification{
+						SourceModelID: m.ID,
+						Level:         bubbletree.SuccessNotice,
+						Text:          "Settings saved",
+					}),
+				)
 			}
 		}
 	}
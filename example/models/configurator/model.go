@@ -30,13 +30,14 @@ var (
 
 // New creates and initializes a new model ready to be used.
 func New(opts ...Option) bubbletree.LeafModel {
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancelCause(context.Background())
 	m := &Model{
 		DefaultLeafModel: bubbletree.DefaultLeafModel{
 			DefaultCommonModel: bubbletree.DefaultCommonModel{
 				ID:     fmt.Sprintf("%s-%d", modelName, lastID.Add(1)),
 				Ctx:    ctx,
 				Cancel: cancel,
+				Tasks:  bubbletree.NewTaskRunner(),
 			},
 		},
 	}
@@ -78,8 +79,7 @@ func (m Model) Update(msg tea.Msg) (bubbletree.LeafModel, tea.Cmd) {
 	// The configuration file could not be found, read from user input form.
 	case ConfigMissingMsg:
 		if m.IsInactive() {
-			m.State = bubbletree.ActiveState
-			m.LogStateChange(msg)
+			m.SetState(bubbletree.ActiveState, msg)
 		}
 
 		m.formCompleted = false
@@ -99,6 +99,13 @@ func (m Model) Update(msg tea.Msg) (bubbletree.LeafModel, tea.Cmd) {
 			m.LogNotice(msg, "Form completed")
 		}
 
+	// Saving the configuration failed, start over.
+	case bubbletree.RetryMsg:
+		if msg.IsRecipient(m.ID) {
+			cmds = append(cmds, GetConfigCmd(m.Viper, true))
+			m.LogAction(msg, "Requesting configuration retry")
+		}
+
 	// When a configuration session is cancelled.
 	case ConfigCancelMsg:
 		if m.IsActive() {
@@ -114,6 +121,11 @@ func (m Model) Update(msg tea.Msg) (bubbletree.LeafModel, tea.Cmd) {
 	}
 	cmds = append(cmds, cmd)
 
+	// Read-only, the form is shown but the settings can't be edited.
+	if _, ok := msg.(tea.KeyMsg); ok && m.IsReadOnly() {
+		return m, tea.Batch(cmds...)
+	}
+
 	// Run Huh Forms until we're done capturing config.
 	if m.form != nil && !m.formCompleted && m.IsActive() {
 		model, cmd := m.updateForm(msg)
@@ -138,7 +150,7 @@ func (m Model) View(w, h int) string {
 			}
 			return m.Theme.GetBaseStyle().Render(formView)
 		}
-		return m.Theme.RenderNormalText("Settings Saved!")
+		return m.Theme.RenderNormalText("Settings are up to date")
 	}
 	return ""
 }
@@ -148,7 +160,8 @@ func (m Model) GetViewHeader(w, h int) string {
 	return m.Theme.RenderNormalText("Acquiring System Configuration")
 }
 
-// GetViewFooter returns the model's footer view string.
+// GetViewFooter returns the model's footer view string, the form errors if
+// any. The key bindings are rendered otherwise.
 func (m Model) GetViewFooter(w, h int) string {
 	var s string
 	if m.form == nil {
@@ -157,12 +170,31 @@ func (m Model) GetViewFooter(w, h int) string {
 	for _, err := range m.form.Errors() {
 		s += err.Error()
 	}
-	if s == "" {
-		s = m.form.Help().ShortHelpView(m.form.KeyBinds())
-	}
 	return s
 }
 
+// KeyBindings implements the bubbletree.KeyBinder interface, declaring the
+// key bindings of the form's focused field. None are declared while a text
+// field is focused, so that it receives the help keys, or when read-only.
+func (m Model) KeyBindings() []bubbletree.KeyBinding {
+	if m.form == nil || m.formCompleted || m.IsReadOnly() {
+		return nil
+	}
+	if _, ok := m.form.GetFocusedField().(*huh.Input); ok {
+		return nil
+	}
+	var bindings []bubbletree.KeyBinding
+	for _, b := range m.form.KeyBinds() {
+		bindings = append(bindings, bubbletree.KeyBinding{
+			Keys:     b.Keys(),
+			Help:     b.Help().Key,
+			Desc:     b.Help().Desc,
+			Disabled: !b.Enabled(),
+		})
+	}
+	return bindings
+}
+
 // Options
 
 // Option is used to set options for the new model at creation.
@@ -186,12 +218,24 @@ func WithTheme(theme bubbletree.Themer) Option {
 	}
 }
 
+func WithEventBus(bus *bubbletree.EventBus) Option {
+	return func(m *Model) {
+		m.Bus = bus
+	}
+}
+
 func WithDisabled() Option {
 	return func(m *Model) {
 		m.Properties |= bubbletree.Disabled
 	}
 }
 
+func WithReadOnly() Option {
+	return func(m *Model) {
+		m.Properties |= bubbletree.ReadOnly
+	}
+}
+
 func WithReconfigure(force bool) Option {
 	return func(m *Model) {
 		m.reconf = force
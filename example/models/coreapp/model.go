@@ -7,17 +7,20 @@ package coreapp
 import (
 	"context"
 	"fmt"
-	"sync"
+	"io"
 	"sync/atomic"
 	"time"
 
+	"example/internal/app"
 	"example/models/configurator"
+	"example/models/notices"
 	"example/ui/components"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/yhcote/bubbletree"
 	"github.com/yhcote/bubbletree/logger"
+	"github.com/yhcote/bubbletree/logview"
 )
 
 const (
@@ -30,9 +33,65 @@ var (
 	lastID atomic.Int64
 )
 
-// Run creates and initializes a new model ready to be used.
-func Run(opts ...bubbletree.AppOption) error {
-	ctx, cancel := context.WithCancel(context.Background())
+// keys are the model's key bindings, handled whatever descendant model is in
+// focus.
+var keys = struct {
+	Quit      bubbletree.KeyBinding
+	Dashboard bubbletree.KeyBinding
+	Settings  bubbletree.KeyBinding
+	Logs      bubbletree.KeyBinding
+	Notices   bubbletree.KeyBinding
+}{
+	Quit:      bubbletree.KeyBinding{Keys: []string{"ctrl+c", "esc"}, Help: "esc", Desc: "quit", Scope: bubbletree.GlobalScope},
+	Dashboard: bubbletree.KeyBinding{Keys: []string{"f1"}, Desc: "dashboard", Scope: bubbletree.GlobalScope},
+	Settings:  bubbletree.KeyBinding{Keys: []string{"f2"}, Desc: "settings", Scope: bubbletree.GlobalScope},
+	Logs:      bubbletree.KeyBinding{Keys: []string{"f3"}, Desc: "logs", Scope: bubbletree.GlobalScope},
+	Notices:   bubbletree.KeyBinding{Keys: []string{"f4"}, Desc: "notices", Scope: bubbletree.GlobalScope},
+}
+
+// Run creates the application model tree and runs the bubble tea program
+// until it quits. The root model options, e.g., bubbletree.WithRecorder, are
+// passed along. The root model controls the default logger levels.
+func Run(rootOpts []bubbletree.RootOption, opts ...bubbletree.AppOption) error {
+	m, err := newModel(opts...)
+	if err != nil {
+		return err
+	}
+	rootOpts = append([]bubbletree.RootOption{bubbletree.WithLevelController(logger.Levels{})}, rootOpts...)
+
+	// Create a root model shim to the bubble tea framework and start the
+	// event loop engine.
+	if teaProgram, err := tea.NewProgram(bubbletree.New(m, rootOpts...), tea.WithAltScreen(), tea.WithMouseCellMotion()).Run(); err != nil {
+		return err
+	} else if teaProgram.(bubbletree.RootModel).LastError() != nil {
+		return teaProgram.(bubbletree.RootModel).LastError()
+	}
+
+	return nil
+}
+
+// Replay creates the application model tree and replays the messages
+// recorded from a previous run, see bubbletree.Replay.
+func Replay(r io.Reader, replayOpts []bubbletree.ReplayOption, opts ...bubbletree.AppOption) error {
+	m, err := newModel(opts...)
+	if err != nil {
+		return err
+	}
+
+	replayOpts = append([]bubbletree.ReplayOption{
+		bubbletree.WithProgramOptions(tea.WithAltScreen(), tea.WithMouseCellMotion()),
+		bubbletree.WithRootOptions(bubbletree.WithLevelController(logger.Levels{})),
+	}, replayOpts...)
+	root, err := bubbletree.Replay(m, r, replayOpts...)
+	if err != nil {
+		return err
+	}
+	return root.LastError()
+}
+
+// newModel creates and initializes a new model ready to be used.
+func newModel(opts ...bubbletree.AppOption) (Model, error) {
+	ctx, cancel := context.WithCancelCause(context.Background())
 	m := Model{
 		DefaultAppModel: bubbletree.DefaultAppModel{
 			DefaultBranchModel: bubbletree.DefaultBranchModel{
@@ -40,7 +99,10 @@ func Run(opts ...bubbletree.AppOption) error {
 					ID:     fmt.Sprintf("%s-%d", modelName, lastID.Add(1)),
 					Ctx:    ctx,
 					Cancel: cancel,
+					Tasks:  bubbletree.NewTaskRunner(),
 				},
+				CatchErrors:    true,
+				DisableOnPanic: true,
 			},
 		},
 	}
@@ -57,7 +119,7 @@ func Run(opts ...bubbletree.AppOption) error {
 		m.OptLogger = logger.Log()
 	}
 	if m.OptConfigViper == nil {
-		return fmt.Errorf("configuration through 'viper' is expected, pass the 'WithConfigViper' option")
+		return Model{}, fmt.Errorf("configuration through 'viper' is expected, pass the 'WithConfigViper' option")
 	}
 	if m.OptSpewcfg == nil {
 		m.OptSpewcfg = spew.NewDefaultConfig()
@@ -65,36 +127,45 @@ func Run(opts ...bubbletree.AppOption) error {
 	if m.OptTheme == nil {
 		m.OptTheme = bubbletree.DefaultMinimalTheme()
 	}
+	if m.OptEventBus == nil {
+		m.OptEventBus = bubbletree.NewEventBus()
+	}
 	m.Logger = m.OptLogger
 	m.Viper = m.OptConfigViper
 	m.Theme = m.OptTheme
+	m.Bus = m.OptEventBus
 
 	// Create and link all descendant models used in the application.
-	m.Models = new(sync.Map)
+	m.Models = bubbletree.NewRegistry()
 
 	// Add the Configurator Model.
 	model := configurator.New(
 		configurator.WithLogger(m.Logger),
 		configurator.WithViper(m.Viper),
 		configurator.WithTheme(m.Theme),
+		configurator.WithEventBus(m.Bus),
 		configurator.WithReconfigure(m.OptReconf),
 	)
 	m.LinkNewModel(model, &m.modelConfigID)
 
-	// focusedID to self to cover View invocations during bootstrap time.
-	m.focusedID = m.ID
+	// Add the Notification History Model.
+	model = notices.New(
+		notices.WithLogger(m.Logger),
+		notices.WithTheme(m.Theme),
+	)
+	m.LinkNewModel(model, &m.modelNoticesID)
 
-	m.Logger.Info("New model created", "ModelID", m.ID)
+	// Add the Log Viewer Model.
+	model = logview.New(
+		logview.WithLogger(m.Logger),
+		logview.WithTheme(m.Theme),
+	)
+	m.LinkNewModel(model, &m.modelLogsID)
 
-	// Create a root model shim to the bubble tea framework and start the
-	// event loop engine.
-	if teaProgram, err := tea.NewProgram(bubbletree.New(m), tea.WithAltScreen()).Run(); err != nil {
-		return err
-	} else if teaProgram.(bubbletree.RootModel).LastError() != nil {
-		return teaProgram.(bubbletree.RootModel).LastError()
-	}
+	m.Logger.Info("New model created", "ModelID", m.ID)
+	m.Logger.Debug("Model tree\n" + bubbletree.DumpText(m))
 
-	return nil
+	return m, nil
 }
 
 // Model is the definition of the coreapp model.
@@ -103,10 +174,9 @@ type Model struct {
 	bubbletree.DefaultAppModel
 
 	// Direct child models saved IDs for direct and easy access.
-	modelConfigID string
-
-	// Focused Model, receives console input events (keyboard/mouse)
-	focusedID string
+	modelConfigID  string
+	modelNoticesID string
+	modelLogsID    string
 
 	// UI related variables.
 	topbar    *components.Winbar
@@ -121,15 +191,11 @@ func (m Model) Init() tea.Cmd {
 	cmd := tea.SetWindowTitle(fmt.Sprintf("%s  ver: %s", m.OptProgname, m.OptProgver))
 	cmds = append(cmds, cmd)
 
+	// Get notified of configuration changes.
+	bubbletree.Subscribe(m.Bus, app.ConfigSaved, m.ID, m.Ctx)
+
 	// Run all descendant's Init() routine and collect their returned tea Cmds.
-	m.Models.Range(func(key, value any) bool {
-		if model, ok := value.(bubbletree.CommonModel); ok {
-			cmds = append(cmds, model.Init())
-		} else {
-			panic("stored model isn't of bubbletree.CommonModel")
-		}
-		return true
-	})
+	cmds = append(cmds, m.InitNodeModels())
 	return tea.Batch(cmds...)
 }
 
@@ -146,19 +212,19 @@ func (m Model) Update(msg tea.Msg) (bubbletree.BranchModel, tea.Cmd) {
 
 	t1 := time.Now()
 	switch msg := msg.(type) {
-	// Console input is usually passed down to the model it as identified
-	// as in focus. Special keys to quit or Function Keys to switch model
-	// tabs are handled here and not passed down to descendant models.
+	// Console input is passed down by the framework to the model in focus.
+	// Special keys to quit or Function Keys to switch model tabs are
+	// handled here and not passed down to descendant models.
 	case tea.KeyMsg:
 		m.Logger.Debug("Message", "tea.KeyMsg", m.OptSpewcfg.Sprintf("%#+v", msg))
-		switch msg.String() {
-		case "ctrl+c", "esc":
-			m.LogAction(msg, "Requesting Model Finished")
-			return m, bubbletree.ModelFinishedCmd(m.ID)
-		case "f1":
-			if m.focusedID != m.ID {
+		switch {
+		case bubbletree.Matches(msg, keys.Quit):
+			m.LogAction(msg, "Requesting Model Shutdown")
+			return m, bubbletree.ShutDownCmd([]string{m.ID})
+		case bubbletree.Matches(msg, keys.Dashboard):
+			if m.focusedID() != m.ID {
 				// If we starting a config session (prior f2), end it.
-				if m.focusedID == m.modelConfigID {
+				if m.focusedID() == m.modelConfigID {
 					cmds = append(cmds,
 						configurator.CancelConfigCmd(),
 					)
@@ -166,21 +232,23 @@ func (m Model) Update(msg tea.Msg) (bubbletree.BranchModel, tea.Cmd) {
 				}
 
 				m.tabber.SetActiveTab(0)
-				m.focusedID = m.ID
+				cmds = append(cmds, bubbletree.SetFocusCmd(m.ID))
 			}
-		case "f2":
-			if m.focusedID != m.modelConfigID {
+			return m, tea.Batch(cmds...)
+		case bubbletree.Matches(msg, keys.Settings):
+			if m.focusedID() != m.modelConfigID {
 				m.tabber.SetActiveTab(1)
-				m.focusedID = m.modelConfigID
 				cmds = append(cmds,
+					bubbletree.SetFocusCmd(m.modelConfigID),
 					configurator.GetConfigCmd(m.Viper, true),
 				)
 				m.LogAction(msg, "Requesting (forced) configuration")
 			}
-		case "f3":
-			if m.focusedID != m.ID {
+			return m, tea.Batch(cmds...)
+		case bubbletree.Matches(msg, keys.Logs):
+			if m.focusedID() != m.modelLogsID {
 				// If we starting a config session (prior f2), end it.
-				if m.focusedID == m.modelConfigID {
+				if m.focusedID() == m.modelConfigID {
 					cmds = append(cmds,
 						configurator.CancelConfigCmd(),
 					)
@@ -188,15 +256,23 @@ func (m Model) Update(msg tea.Msg) (bubbletree.BranchModel, tea.Cmd) {
 				}
 
 				m.tabber.SetActiveTab(2)
-				m.focusedID = m.ID // Set to self (coreapp), for yet to be handled tabs.
+				cmds = append(cmds, bubbletree.SetFocusCmd(m.modelLogsID))
 			}
-		default:
-			// If NOT Self (Coreapp)...
-			if m.focusedID != m.ID {
-				// Get the focused model, give it access to input events.
-				cmds = append(cmds, m.UpdateNodeModel(m.MustGetModel(m.focusedID), msg))
-				return m, tea.Batch(cmds...)
+			return m, tea.Batch(cmds...)
+		case bubbletree.Matches(msg, keys.Notices):
+			if m.focusedID() != m.modelNoticesID {
+				// If we starting a config session (prior f2), end it.
+				if m.focusedID() == m.modelConfigID {
+					cmds = append(cmds,
+						configurator.CancelConfigCmd(),
+					)
+					m.LogAction(msg, "Requesting configuration cancellation")
+				}
+
+				m.tabber.SetActiveTab(3)
+				cmds = append(cmds, bubbletree.SetFocusCmd(m.modelNoticesID))
 			}
+			return m, tea.Batch(cmds...)
 		}
 
 	// Tea always sends at least one WindowSizeMsg at startup, use this
@@ -208,8 +284,7 @@ func (m Model) Update(msg tea.Msg) (bubbletree.BranchModel, tea.Cmd) {
 
 		m.Logger.Debug("Message", "tea.WindowSizeMsg", m.OptSpewcfg.Sprintf("%#+v", msg))
 		if m.IsInactive() {
-			m.State = bubbletree.ActiveState
-			m.LogStateChange(msg)
+			m.SetState(bubbletree.ActiveState, msg)
 
 			// Initialize the window top bar.
 			m.topbar = components.NewWinbar(m.Theme, true, 0, 0)
@@ -219,13 +294,14 @@ func (m Model) Update(msg tea.Msg) (bubbletree.BranchModel, tea.Cmd) {
 				{Name: "Dashboard", ShortcutKey: "f1"},
 				{Name: "Settings", ShortcutKey: "f2"},
 				{Name: "Logs", ShortcutKey: "f3"},
+				{Name: "Notices", ShortcutKey: "f4"},
 			}, 0, 0)
 
 			// Initialize the window bottom bar.
 			m.bottombar = components.NewWinbar(m.Theme, false, 0, 0)
 
 			// Switch to main view
-			m.focusedID = m.ID
+			cmds = append(cmds, bubbletree.SetFocusCmd(m.ID))
 
 			// Get the system config.
 			cmds = append(cmds, configurator.GetConfigCmd(m.Viper, m.OptReconf))
@@ -236,10 +312,16 @@ func (m Model) Update(msg tea.Msg) (bubbletree.BranchModel, tea.Cmd) {
 	case configurator.ConfigMissingMsg:
 		if m.IsActive() {
 			m.tabber.SetActiveTab(1)
-			m.focusedID = m.modelConfigID
+			cmds = append(cmds, bubbletree.SetFocusCmd(m.modelConfigID))
 
 			m.LogAction(msg, "Requesting switch to configuration tab")
 		}
+
+	// A new configuration was saved by the configurator.
+	case bubbletree.Event[app.Config]:
+		if msg.Topic == app.ConfigSaved {
+			m.LogNotice(msg, "Configuration saved by "+msg.Publisher)
+		}
 	}
 
 	// Run the default message handlers from bubbletree.
@@ -258,6 +340,22 @@ func (m Model) Update(msg tea.Msg) (bubbletree.BranchModel, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// KeyBindings implements the bubbletree.KeyBinder interface, declaring the
+// model's key bindings.
+func (m Model) KeyBindings() []bubbletree.KeyBinding {
+	return []bubbletree.KeyBinding{keys.Quit, keys.Dashboard, keys.Settings, keys.Logs, keys.Notices, bubbletree.LogLevelKey}
+}
+
+// focusedID returns the ID of the descendant model in focus, which receives
+// console input events (keyboard/mouse), or the ID of self (coreapp) when no
+// descendant has the focus.
+func (m Model) focusedID() string {
+	if model, ok := m.FocusedModel(); ok {
+		return model.GetModelID()
+	}
+	return m.ID
+}
+
 // AppView implements the appnode interface method allowing the rootnode to
 // call the application's view method, passing whether the application is
 // quitting or not. When quitting an optional program error is also passed
@@ -7,7 +7,7 @@ package coreapp
 import (
 	"fmt"
 
-	"github.com/charmbracelet/lipgloss"
+	"github.com/yhcote/bubbletree"
 	"github.com/yhcote/bubbletree/logger"
 )
 
@@ -19,16 +19,12 @@ const (
 // renderNormalWindow renders the complete window view for the normally
 // executing program.
 func (m Model) renderNormalWindow(maxWidth, maxHeight int) string {
-	header := m.renderHeader(maxWidth, topBarMaxHeight)
-	header = sureFit(header, maxWidth, topBarMaxHeight)
-
-	footer := m.renderFooter(maxWidth, bottomBarMaxHeight)
-	footer = sureFit(footer, maxWidth, bottomBarMaxHeight)
-
-	content := m.renderContent(maxWidth, maxHeight-(lipgloss.Height(header)+lipgloss.Height(footer)))
-	content = sureFit(content, maxWidth, maxHeight-(lipgloss.Height(header)+lipgloss.Height(footer)))
-
-	return lipgloss.JoinVertical(lipgloss.Left, header, content, footer)
+	layout := bubbletree.VStack(
+		bubbletree.LayoutItem{ID: "header", Render: m.renderHeader, Size: bubbletree.Fixed(topBarMaxHeight)},
+		bubbletree.LayoutItem{ID: "content", Render: m.renderContent, Size: bubbletree.Flex(1)},
+		bubbletree.LayoutItem{ID: "footer", Render: m.renderFooter, Size: bubbletree.Fixed(bottomBarMaxHeight)},
+	)
+	return m.renderLayout(layout, maxWidth, maxHeight)
 }
 
 // renderHeader renders the top bar of the window. This is the status or info
@@ -38,14 +34,15 @@ func (m Model) renderHeader(maxWidth, maxHeight int) string {
 		return ""
 	}
 
-	s := m.Theme.RenderSecondaryText(m.OptProgname + " / " + m.focusedID)
-	if m.focusedID != m.ID {
+	s := m.Theme.RenderSecondaryText(m.OptProgname + " / " + m.focusedID())
+	if m.focusedID() != m.ID {
 		// Get the focused model and generate its current view header.
-		header := m.MustGetModel(m.focusedID).GetViewHeader(maxWidth, maxHeight)
+		header := m.MustGetModel(m.focusedID()).GetViewHeader(maxWidth, maxHeight)
 		if header != "" {
 			s += m.Theme.RenderPrimaryText(" • ") + header
 		}
 	}
+	s += m.Theme.RenderPrimaryText(" • ") + m.Theme.RenderSecondaryText("log "+logger.Level().String())
 	m.topbar.SetContent(s)
 
 	return m.topbar.Render(maxWidth, maxHeight)
@@ -55,21 +52,16 @@ func (m Model) renderHeader(maxWidth, maxHeight int) string {
 // application output. It's also the middle zone of the application window.
 func (m Model) renderContent(maxWidth, maxHeight int) string {
 	// Self (coreapp) is in focus, deal with general possible views.
-	if m.focusedID == m.ID {
+	if m.focusedID() == m.ID {
 		switch m.tabber.GetActiveTab() {
 		case 0:
 			m.tabber.SetContent(m.Theme.RenderNormalText("Program initializing"))
-		case 2:
-			m.tabber.SetContent(
-				m.Theme.RenderNormalText("\nLog Window Unimplemented, Check ") +
-					m.Theme.RenderPrimaryText(logger.GetLoggerOutputName()),
-			)
 		default:
 			m.tabber.SetContent(m.Theme.RenderNormalText("unknown active tab index"))
 		}
 	} else {
 		// Get the focused model and generate its current state view.
-		content := m.MustGetModel(m.focusedID).View(maxWidth, maxHeight)
+		content := m.ViewNodeModel(m.MustGetModel(m.focusedID()), maxWidth, maxHeight)
 		m.tabber.SetContent(content)
 	}
 
@@ -83,12 +75,11 @@ func (m Model) renderFooter(maxWidth, maxHeight int) string {
 		return m.Theme.RenderSecondaryText("Program Initializing...")
 	}
 
-	s := m.Theme.RenderSecondaryText("Press ") +
-		m.Theme.RenderPrimaryText("ESC") +
-		m.Theme.RenderSecondaryText(" to quit")
-	if m.focusedID != m.ID {
-		// Get the focused model and generate its current view footer.
-		footer := m.MustGetModel(m.focusedID).GetViewFooter(maxWidth, maxHeight)
+	s := bubbletree.ShortHelp(m.Theme, m.KeyBindings())
+	if m.focusedID() != m.ID {
+		// Get the focused model and generate its current view footer, or
+		// its key bindings help when declared.
+		footer := bubbletree.ViewFooter(m.MustGetModel(m.focusedID()), maxWidth, maxHeight)
 		if footer != "" {
 			s += m.Theme.RenderPrimaryText(" • ") + footer
 		}
@@ -102,41 +93,27 @@ func (m Model) renderFooter(maxWidth, maxHeight int) string {
 // program. It should clearly display the error that caused the exit in case
 // of abnormal exit.
 func (m Model) renderQuittingWindow(err error, maxWidth, maxHeight int) string {
-	header := m.renderHeader(maxWidth, topBarMaxHeight)
-	header = sureFit(header, maxWidth, topBarMaxHeight)
-
-	footer := ""
+	footerHeight := 0
 	if err != nil {
-		footer = m.Theme.RenderErrorText(fmt.Sprintf("ERROR: %v", err))
-		footer = sureFit(footer, maxWidth, bottomBarMaxHeight)
+		footerHeight = bottomBarMaxHeight
 	}
-
-	content := m.renderContent(maxWidth, maxHeight-(lipgloss.Height(header)+lipgloss.Height(footer)))
-	content = sureFit(content, maxWidth, maxHeight-(lipgloss.Height(header)+lipgloss.Height(footer)))
-
-	return lipgloss.JoinVertical(lipgloss.Left, header, content, footer)
+	layout := bubbletree.VStack(
+		bubbletree.LayoutItem{ID: "header", Render: m.renderHeader, Size: bubbletree.Fixed(topBarMaxHeight)},
+		bubbletree.LayoutItem{ID: "content", Render: m.renderContent, Size: bubbletree.Flex(1)},
+		bubbletree.LayoutItem{ID: "error", Size: bubbletree.Fixed(footerHeight), Render: func(w, h int) string {
+			return m.Theme.RenderErrorText(fmt.Sprintf("ERROR: %v", err))
+		}},
+	)
+	return m.renderLayout(layout, maxWidth, maxHeight)
 }
 
-// sureFit makes sure that view strings built by decendant models respected
-// maxWidth and maxHeight. In case reviewed strings don't fit in their zones,
-// this function will force truncate them to fit.
-func sureFit(str string, maxWidth, maxHeight int) string {
-	// When remaining space has no columns or rows left, return an empty
-	// string.
-	if maxWidth <= 0 || maxHeight <= 0 {
-		logger.Log().Error("string element will not fit (0 length width or height)",
-			"width", maxWidth, "height", maxHeight, "strWidth", lipgloss.Width(str),
-			"strHeight", lipgloss.Height(str))
-		return ""
+// renderLayout renders the window layout and logs the zones whose content
+// didn't fit their allocated space and got truncated.
+func (m Model) renderLayout(layout *bubbletree.Layout, maxWidth, maxHeight int) string {
+	res := layout.Render(maxWidth, maxHeight)
+	for _, o := range res.Overflows {
+		m.Logger.Error("view element doesn't fit", "zone", o.ID, "width", o.Width, "height", o.Height,
+			"strWidth", o.ContentWidth, "strHeight", o.ContentHeight)
 	}
-
-	// When remaining space cannot fit the size of the string completely,
-	// shoehorn (truncate) that string into available space.
-	if maxHeight < lipgloss.Height(str) || maxWidth < lipgloss.Width(str) {
-		logger.Log().Error("string element doesn't fit", "width", maxWidth, "height", maxHeight,
-			"strWidth", lipgloss.Width(str), "strHeight", lipgloss.Height(str))
-		return lipgloss.NewStyle().MaxWidth(maxWidth).MaxHeight(maxHeight).Render(str)
-	}
-
-	return str
+	return res.View
 }
@@ -0,0 +1,206 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package notices
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/yhcote/bubbletree"
+)
+
+const (
+	// short model name used for identification.
+	modelName = "notices"
+
+	// default number of notifications kept in the history.
+	defaultHistorySize = 100
+)
+
+var (
+	// unique model instance id based on 'modelName'.
+	lastID atomic.Int64
+)
+
+// keys are the model's key bindings, handled when the model is in focus.
+var keys = struct {
+	Up    bubbletree.KeyBinding
+	Down  bubbletree.KeyBinding
+	Clear bubbletree.KeyBinding
+}{
+	Up:    bubbletree.KeyBinding{Keys: []string{"up", "k"}, Help: "↑/k", Desc: "newer"},
+	Down:  bubbletree.KeyBinding{Keys: []string{"down", "j"}, Help: "↓/j", Desc: "older"},
+	Clear: bubbletree.KeyBinding{Keys: []string{"x"}, Desc: "clear"},
+}
+
+// New creates and initializes a new model ready to be used.
+func New(opts ...Option) bubbletree.LeafModel {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	m := &Model{
+		DefaultLeafModel: bubbletree.DefaultLeafModel{
+			DefaultCommonModel: bubbletree.DefaultCommonModel{
+				ID:     fmt.Sprintf("%s-%d", modelName, lastID.Add(1)),
+				Ctx:    ctx,
+				Cancel: cancel,
+			},
+		},
+		size: defaultHistorySize,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	m.Logger.Info("New model created", "ModelID", m.ID)
+	return m
+}
+
+// Model is the notification history model. It records the notifications
+// passed down the tree by the root model and lets the user review them.
+type Model struct {
+	// Include fields and default methods of bubbletree.DefaultLeafModel.
+	bubbletree.DefaultLeafModel
+
+	// The maximum number of notifications kept.
+	size int
+
+	// The notifications history, newest first.
+	history []bubbletree.Notification
+
+	// The index of the first notification shown.
+	offset int
+}
+
+// Update is responsible for accepting a tea message passed down from the
+// parent model and update the model data when appropriate.
+func (m Model) Update(msg tea.Msg) (bubbletree.LeafModel, tea.Cmd) {
+	var (
+		cmds []tea.Cmd
+		ok   bool
+	)
+	if m.IsDisabled() {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	// Start recording with the program's first screen size report.
+	case tea.WindowSizeMsg:
+		if m.IsInactive() {
+			m.SetState(bubbletree.ActiveState, msg)
+		}
+
+	// Record the displayed notifications.
+	case bubbletree.NotifyMsg:
+		m.history = append([]bubbletree.Notification{msg.Notification}, m.history...)
+		if len(m.history) > m.size {
+			m.history = m.history[:m.size]
+		}
+		if m.offset > 0 {
+			m.offset = min(m.offset+1, len(m.history)-1)
+		}
+
+	// Scroll through the history.
+	case tea.KeyMsg:
+		switch {
+		case bubbletree.Matches(msg, keys.Up):
+			m.offset = max(m.offset-1, 0)
+		case bubbletree.Matches(msg, keys.Down):
+			m.offset = max(min(m.offset+1, len(m.history)-1), 0)
+		case bubbletree.Matches(msg, keys.Clear):
+			m.history, m.offset = nil, 0
+			m.LogNotice(msg, "Notification history cleared")
+		}
+
+	// Scroll through the history with the mouse wheel.
+	case tea.MouseMsg:
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			m.offset = max(m.offset-1, 0)
+		case tea.MouseButtonWheelDown:
+			m.offset = max(min(m.offset+1, len(m.history)-1), 0)
+		}
+	}
+
+	// Run the default message handlers from bubbletree.
+	leafModel, cmd := m.DefaultLeafModel.Update(msg)
+	if m.DefaultLeafModel, ok = leafModel.(bubbletree.DefaultLeafModel); !ok {
+		panic("DefaultLeafModel.Update didn't returned 'leadModel' as expected 'bubbletree.DefaultLeafModel' type")
+	}
+	cmds = append(cmds, cmd)
+
+	return m, tea.Batch(cmds...)
+}
+
+// View is the model's rendering routine that creates the output reflecting
+// the current state of the model data. The rendered string is passed back up
+// to the root model for final window composition.
+func (m Model) View(w, h int) string {
+	if !m.IsActive() {
+		return ""
+	}
+	if len(m.history) == 0 {
+		return m.Theme.RenderSecondaryText("No notifications")
+	}
+
+	var b strings.Builder
+	for i, note := range m.history[m.offset:] {
+		if i >= h {
+			break
+		}
+		line := note.Time.Format("15:04:05") + " " + fmt.Sprintf("%-7s", note.Level) + " " + note.Text
+		if note.SourceModelID != "" {
+			line += " (" + note.SourceModelID + ")"
+		}
+		switch note.Level {
+		case bubbletree.ErrorNotice:
+			line = m.Theme.RenderErrorText(line)
+		case bubbletree.WarningNotice:
+			line = m.Theme.RenderSecondaryText(line)
+		default:
+			line = m.Theme.RenderNormalText(line)
+		}
+		b.WriteString(line + "\n")
+	}
+	return m.Theme.GetBaseStyle().MaxWidth(w).MaxHeight(h).Render(strings.TrimSuffix(b.String(), "\n"))
+}
+
+// GetViewHeader returns the model's header view string.
+func (m Model) GetViewHeader(w, h int) string {
+	return m.Theme.RenderNormalText(fmt.Sprintf("Notification History (%d)", len(m.history)))
+}
+
+// KeyBindings implements the bubbletree.KeyBinder interface, declaring the
+// model's key bindings.
+func (m Model) KeyBindings() []bubbletree.KeyBinding {
+	return []bubbletree.KeyBinding{keys.Up, keys.Down, keys.Clear}
+}
+
+// Options
+
+// Option is used to set options for the new model at creation.
+type Option func(*Model)
+
+func WithLogger(logger *slog.Logger) Option {
+	return func(m *Model) {
+		m.Logger = logger
+	}
+}
+
+func WithTheme(theme bubbletree.Themer) Option {
+	return func(m *Model) {
+		m.Theme = theme
+	}
+}
+
+func WithHistorySize(size int) Option {
+	return func(m *Model) {
+		if size > 0 {
+			m.size = size
+		}
+	}
+}
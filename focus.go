@@ -0,0 +1,310 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Focusable is implemented by models taking part in the focus ring: the
+// ordered list of models visited by the focus traversal keys (Tab and
+// Shift-Tab by default). The ring follows the tree depth-first, in registry
+// order. Models that don't implement the interface can still be focused with
+// SetFocusCmd, but are skipped by traversal, as are disabled and hidden
+// models.
+type Focusable interface {
+	// CanFocus returns whether the model currently accepts the focus.
+	CanFocus() bool
+}
+
+// FocusManager keeps track of the focus path, the model IDs leading from the
+// core application model down to the focused model, and of the focus stack
+// used by modal models to temporarily take and then give back the focus. It
+// is owned by the root model, which intercepts the focus messages and the
+// traversal keys.
+//
+// Console input (tea.KeyMsg and tea.MouseMsg) is only passed down by branch
+// models to the child on the focus path, that is the focused child or the
+// one holding a focused descendant.
+type FocusManager struct {
+	// Keys moving the focus to the next and previous model of the ring.
+	NextKeys []string
+	PrevKeys []string
+
+	// The current focus path, and the set of its model IDs.
+	path   []string
+	onPath map[string]struct{}
+
+	// The saved focus paths of pushed focus, the last one being restored
+	// first.
+	stack [][]string
+
+	// The models that pushed focus, restricting the ring to their subtree.
+	traps []string
+}
+
+// NewFocusManager returns a new FocusManager with the default traversal
+// keys.
+func NewFocusManager() *FocusManager {
+	return &FocusManager{
+		NextKeys: []string{"tab"},
+		PrevKeys: []string{"shift+tab"},
+	}
+}
+
+// Path returns a copy of the current focus path.
+func (f *FocusManager) Path() []string {
+	return slices.Clone(f.path)
+}
+
+// OnPath returns whether the model identified by id is on the focus path:
+// the focused model or one of its ancestors.
+func (f *FocusManager) OnPath(id string) bool {
+	_, ok := f.onPath[id]
+	return ok
+}
+
+// setPath sets the focus path.
+func (f *FocusManager) setPath(path []string) {
+	f.path = path
+	f.onPath = make(map[string]struct{}, len(path))
+	for _, id := range path {
+		f.onPath[id] = struct{}{}
+	}
+}
+
+// FocusedID returns the ID of the focused model, or an empty string when no
+// model has the focus.
+func (f *FocusManager) FocusedID() string {
+	if len(f.path) == 0 {
+		return ""
+	}
+	return f.path[len(f.path)-1]
+}
+
+// Depth returns the number of pushed focus on the focus stack.
+func (f *FocusManager) Depth() int {
+	return len(f.stack)
+}
+
+// Update handles the focus messages for the model tree under app. It returns
+// whether msg was consumed, in which case it must not be passed down the
+// tree, and the commands to run.
+func (f *FocusManager) Update(app CommonModel, msg tea.Msg) (bool, tea.Cmd) {
+	switch msg := msg.(type) {
+	// Remember the new focus path, the message then goes down the tree.
+	case SetFocusMsg:
+		f.setPath(findPath(app, msg.ModelID))
+		return false, nil
+
+	// An unmounted model may have been on the focus path.
+	case UnmountedMsg:
+		if f.OnPath(msg.ModelID) {
+			f.setPath(findPath(app, f.FocusedID()))
+		}
+		return false, nil
+
+	case FocusNextMsg:
+		return true, f.traverse(app, 1)
+
+	case FocusPrevMsg:
+		return true, f.traverse(app, -1)
+
+	case PushFocusMsg:
+		f.stack = append(f.stack, f.path)
+		f.traps = append(f.traps, msg.ModelID)
+		return true, SetFocusCmd(msg.ModelID)
+
+	case PopFocusMsg:
+		if len(f.stack) == 0 {
+			return true, nil
+		}
+		path := f.stack[len(f.stack)-1]
+		f.stack = f.stack[:len(f.stack)-1]
+		f.traps = f.traps[:len(f.traps)-1]
+		if len(path) == 0 {
+			return true, SetFocusCmd("")
+		}
+		return true, SetFocusCmd(path[len(path)-1])
+
+	// Traversal keys are only intercepted when the focus ring is in use,
+	// otherwise they belong to the focused model.
+	case tea.KeyMsg:
+		dir := 0
+		if slices.Contains(f.NextKeys, msg.String()) {
+			dir = 1
+		} else if slices.Contains(f.PrevKeys, msg.String()) {
+			dir = -1
+		}
+		if dir == 0 {
+			return false, nil
+		}
+		ring := f.ring(app)
+		if len(ring) == 0 || (f.FocusedID() != "" && !slices.Contains(ring, f.FocusedID())) {
+			return false, nil
+		}
+		return true, f.traverse(app, dir)
+	}
+	return false, nil
+}
+
+// ring returns the IDs of the focusable models, restricted to the subtree of
+// the last model that pushed focus, if any.
+func (f *FocusManager) ring(app CommonModel) []string {
+	top := app
+	if len(f.traps) > 0 {
+		if model, ok := findModel(app, f.traps[len(f.traps)-1]); ok {
+			top = model
+		}
+	}
+
+	var ids []string
+	var collect func(model CommonModel)
+	collect = func(model CommonModel) {
+		if focusable, ok := model.(Focusable); ok && focusable.CanFocus() && model.GetProperties()&(Disabled|Hidden) == 0 {
+			ids = append(ids, model.GetModelID())
+		}
+		if ranger, ok := model.(modelRanger); ok {
+			ranger.RangeModels(func(child CommonModel) bool {
+				collect(child)
+				return true
+			})
+		}
+	}
+	collect(top)
+	return ids
+}
+
+// traverse returns the command moving the focus dir steps along the ring.
+func (f *FocusManager) traverse(app CommonModel, dir int) tea.Cmd {
+	ring := f.ring(app)
+	if len(ring) == 0 {
+		return nil
+	}
+	i := slices.Index(ring, f.FocusedID())
+	switch {
+	case i < 0 && dir > 0:
+		i = 0
+	case i < 0:
+		i = len(ring) - 1
+	default:
+		i = (i + dir + len(ring)) % len(ring)
+	}
+	return SetFocusCmd(ring[i])
+}
+
+// FocusedModel returns the direct child model on the focus path: the focused
+// child, or the child holding the focused descendant.
+func (m DefaultBranchModel) FocusedModel() (CommonModel, bool) {
+	var focused CommonModel
+	m.RangeModels(func(model CommonModel) bool {
+		if m.onFocusPath(model) {
+			focused = model
+			return false
+		}
+		return true
+	})
+	return focused, focused != nil
+}
+
+// onFocusPath returns whether the child model is focused or holds a focused
+// descendant. The focus path of the root model focus manager is used when
+// the tree is linked to one, the descendants properties otherwise.
+func (m DefaultBranchModel) onFocusPath(model CommonModel) bool {
+	if f := m.Models.focusManager(); f != nil {
+		return f.OnPath(model.GetModelID())
+	}
+	return model.IsFocused() || registryOf(model).leadsTo(Route{Release: Focused})
+}
+
+// isInput returns whether msg is console input, only delivered along the
+// focus path.
+func isInput(msg tea.Msg) bool {
+	switch msg.(type) {
+	case tea.KeyMsg, tea.MouseMsg:
+		return true
+	}
+	return false
+}
+
+// findModel searches model and its descendants for the model identified by
+// id.
+func findModel(model CommonModel, id string) (CommonModel, bool) {
+	found, _, ok := Find(model, id)
+	return found, ok
+}
+
+// findPath returns the model IDs leading from model down to the model
+// identified by id, or nil if it isn't in the tree.
+func findPath(model CommonModel, id string) []string {
+	if model.GetModelID() == id {
+		return []string{id}
+	}
+	var path []string
+	if ranger, ok := model.(modelRanger); ok {
+		ranger.RangeModels(func(child CommonModel) bool {
+			if sub := findPath(child, id); sub != nil {
+				path = append([]string{model.GetModelID()}, sub...)
+				return false
+			}
+			return true
+		})
+	}
+	return path
+}
+
+// Msg/Cmd's
+
+type (
+	// FocusNextMsg is sent to move the focus to the next model of the focus
+	// ring.
+	FocusNextMsg struct{}
+
+	// FocusPrevMsg is sent to move the focus to the previous model of the
+	// focus ring.
+	FocusPrevMsg struct{}
+
+	// PushFocusMsg is sent to give the focus to a model, typically a modal
+	// one, saving the current focus path to be restored by PopFocusMsg. Until
+	// then, the focus ring is restricted to the model's subtree.
+	PushFocusMsg struct{ ModelID string }
+
+	// PopFocusMsg is sent to restore the focus path saved by the last
+	// PushFocusMsg.
+	PopFocusMsg struct{}
+)
+
+// FocusNextCmd returns a message moving the focus to the next model of the
+// focus ring.
+func FocusNextCmd() tea.Cmd {
+	return func() tea.Msg {
+		return FocusNextMsg{}
+	}
+}
+
+// FocusPrevCmd returns a message moving the focus to the previous model of
+// the focus ring.
+func FocusPrevCmd() tea.Cmd {
+	return func() tea.Msg {
+		return FocusPrevMsg{}
+	}
+}
+
+// PushFocusCmd returns a message giving the focus to a model while saving
+// the current focus path.
+func PushFocusCmd(id string) tea.Cmd {
+	return func() tea.Msg {
+		return PushFocusMsg{ModelID: id}
+	}
+}
+
+// PopFocusCmd returns a message restoring the last saved focus path.
+func PopFocusCmd() tea.Cmd {
+	return func() tea.Msg {
+		return PopFocusMsg{}
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFocusPathInput(t *testing.T) {
+	log := &msgLog{}
+	app := testTree(log)
+	var root tea.Model = New(app)
+	key := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")}
+
+	root, _ = root.Update(SetFocusMsg{ModelID: "pane-1"})
+	root, _ = root.Update(key)
+
+	tests := []struct {
+		id   string
+		want bool
+	}{
+		{"app-1", true},
+		{"tabs-1", true},
+		{"pane-1", true},
+		{"pane-2", false},
+		{"status-1", false},
+	}
+	for _, tt := range tests {
+		if got := slices.Contains(log.got(tt.id), "tea.KeyMsg"); got != tt.want {
+			t.Errorf("%s received the key = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+	if got := root.(DefaultRootModel).Focus.Path(); !slices.Equal(got, []string{"app-1", "tabs-1", "pane-1"}) {
+		t.Errorf("focus path = %v", got)
+	}
+}
+
+func TestFocusPathUnmounted(t *testing.T) {
+	log := &msgLog{}
+	app := testTree(log)
+	var root tea.Model = New(app)
+
+	root, _ = root.Update(SetFocusMsg{ModelID: "pane-1"})
+	app.MustGetModel("tabs-1").(testBranch).Models.Delete("pane-1")
+	root, _ = root.Update(UnmountedMsg{ParentID: "tabs-1", ModelID: "pane-1"})
+
+	focus := root.(DefaultRootModel).Focus
+	if got := focus.Path(); len(got) != 0 {
+		t.Errorf("focus path = %v, want none", got)
+	}
+	if focus.OnPath("tabs-1") {
+		t.Error("tabs-1 still on the focus path")
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// HelpOverlay is the root model built-in help screen. Toggled by the help
+// keys ('?' by default), it lists the active key bindings grouped by model,
+// along with the detected binding conflicts. While visible, it consumes all
+// key input but the quit keys, 'esc' or a help key closes it.
+type HelpOverlay struct {
+	// Keys toggling the help overlay.
+	Keys []string
+
+	// Keys passed through to the model tree while the overlay is displayed,
+	// so that the program can still be quit.
+	QuitKeys []string
+
+	// Whether the overlay is currently displayed.
+	visible bool
+
+	// The last reported binding conflicts, to avoid repeating them.
+	reported string
+}
+
+// NewHelpOverlay returns a new hidden HelpOverlay with the default help and
+// quit keys.
+func NewHelpOverlay() *HelpOverlay {
+	return &HelpOverlay{
+		Keys:     []string{"?"},
+		QuitKeys: []string{"ctrl+c"},
+	}
+}
+
+// Visible returns whether the help overlay is displayed.
+func (h *HelpOverlay) Visible() bool {
+	return h.visible
+}
+
+// Update handles the help keys. It returns whether msg was consumed by the
+// overlay. Help keys only open the overlay when the focused model declares
+// key bindings, so that models taking text input, and declaring none while
+// editing, still receive them.
+func (h *HelpOverlay) Update(app CommonModel, focusedID string, msg tea.Msg) bool {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return false
+	}
+	if h.visible {
+		switch {
+		case slices.Contains(h.QuitKeys, keyMsg.String()):
+			h.visible = false
+			return false
+		case keyMsg.String() == "esc" || slices.Contains(h.Keys, keyMsg.String()):
+			h.visible = false
+		}
+		return true
+	}
+	if !slices.Contains(h.Keys, keyMsg.String()) {
+		return false
+	}
+
+	focused := app
+	if focusedID != "" {
+		if model, ok := findModel(app, focusedID); ok {
+			focused = model
+		}
+	}
+	if binder, ok := focused.(KeyBinder); !ok || len(binder.KeyBindings()) == 0 {
+		return false
+	}
+	h.visible = true
+	return true
+}
+
+// View renders the help overlay listing the active bindings in a w by h
+// window.
+func (h *HelpOverlay) View(theme Themer, active []ModelBindings, w, ht int) string {
+	var b strings.Builder
+
+	b.WriteString(theme.RenderHeaderText("Key Bindings") + "\n")
+	for _, mb := range active {
+		labelWidth := 0
+		for _, kb := range mb.Bindings {
+			labelWidth = max(labelWidth, lipgloss.Width(kb.Label()))
+		}
+
+		b.WriteString("\n" + theme.RenderSecondaryText(mb.ModelID) + "\n")
+		for _, kb := range mb.Bindings {
+			label := kb.Label() + strings.Repeat(" ", labelWidth-lipgloss.Width(kb.Label()))
+			b.WriteString("  " + theme.RenderPrimaryText(label) + "  " + theme.RenderNormalText(kb.Desc) + "\n")
+		}
+	}
+
+	if conflicts := KeyConflicts(active); len(conflicts) > 0 {
+		b.WriteString("\n" + theme.RenderErrorText("Conflicting bindings") + "\n")
+		for _, c := range conflicts {
+			b.WriteString(theme.RenderErrorText(fmt.Sprintf("  %s: %s", c.Key, strings.Join(c.ModelIDs, ", "))) + "\n")
+		}
+	}
+
+	b.WriteString("\n" + theme.RenderSecondaryText(fmt.Sprintf("Press %s or esc to close", strings.Join(h.Keys, ", "))))
+
+	return theme.GetBaseStyle().
+		Padding(1, 2).
+		Width(w).
+		Height(ht).
+		MaxWidth(w).
+		MaxHeight(ht).
+		Render(b.String())
+}
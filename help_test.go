@@ -0,0 +1,83 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// boundLeaf is a test leaf model declaring key bindings.
+type boundLeaf struct {
+	testLeaf
+	bindings []KeyBinding
+}
+
+func (m boundLeaf) KeyBindings() []KeyBinding {
+	return m.bindings
+}
+
+func TestHelpOverlay(t *testing.T) {
+	log := &msgLog{}
+	bound := boundLeaf{newTestLeaf(log, "pane-1"), []KeyBinding{{Keys: []string{"r"}, Desc: "reload"}}}
+	editing := boundLeaf{testLeaf: newTestLeaf(log, "pane-2")}
+	app := newTestApp(log, "app-1", bound, editing)
+	key := func(s string) tea.KeyMsg {
+		if s == "ctrl+c" {
+			return tea.KeyMsg{Type: tea.KeyCtrlC}
+		}
+		if s == "esc" {
+			return tea.KeyMsg{Type: tea.KeyEsc}
+		}
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+	}
+
+	steps := []struct {
+		focused  string
+		key      string
+		consumed bool
+		visible  bool
+	}{
+		{"pane-2", "?", false, false},
+		{"pane-1", "?", true, true},
+		{"pane-1", "r", true, true},
+		{"pane-1", "esc", true, false},
+		{"pane-1", "?", true, true},
+		{"pane-1", "ctrl+c", false, false},
+	}
+	help := NewHelpOverlay()
+	for _, step := range steps {
+		if consumed := help.Update(app, step.focused, key(step.key)); consumed != step.consumed {
+			t.Errorf("%s on %s: consumed = %v, want %v", step.key, step.focused, consumed, step.consumed)
+		}
+		if help.Visible() != step.visible {
+			t.Errorf("%s on %s: visible = %v, want %v", step.key, step.focused, help.Visible(), step.visible)
+		}
+	}
+}
+
+func TestViewFooter(t *testing.T) {
+	tests := []struct {
+		name  string
+		model CommonModel
+		want  string
+	}{
+		{"no bindings", newTestLeaf(&msgLog{}, "pane-1"), ""},
+		{"bindings", boundLeaf{newTestLeaf(&msgLog{}, "pane-1"), []KeyBinding{
+			{Keys: []string{"r"}, Desc: "reload"},
+			{Keys: []string{"ctrl+s"}, Help: "^s", Desc: "save"},
+			{Keys: []string{"x"}, Desc: "delete", Disabled: true},
+		}}, "r reload • ^s save"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ansi.Strip(ViewFooter(tt.model, 80, 1)); got != tt.want {
+				t.Errorf("footer = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
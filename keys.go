@@ -0,0 +1,186 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// KeyScope defines where a key binding is active.
+type KeyScope int
+
+const (
+	// FocusScope bindings are active when the declaring model is on the
+	// focus path.
+	FocusScope KeyScope = iota
+
+	// GlobalScope bindings are active whatever model has the focus.
+	GlobalScope
+)
+
+func (s KeyScope) String() string {
+	switch s {
+	case FocusScope:
+		return "FOCUS"
+	case GlobalScope:
+		return "GLOBAL"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// KeyBinding declares a key binding of a model: the keys triggering it, as
+// reported by tea.KeyMsg.String(), and how it is presented in help views.
+type KeyBinding struct {
+	// The keys triggering the binding, e.g., "ctrl+c", "esc".
+	Keys []string
+
+	// The key label shown in help views. Defaults to the first key.
+	Help string
+
+	// The short description of the bound action, e.g., "quit".
+	Desc string
+
+	// Where the binding is active.
+	Scope KeyScope
+
+	// Disabled bindings don't match key messages and aren't listed in help
+	// views.
+	Disabled bool
+}
+
+// Label returns the key label shown in help views.
+func (b KeyBinding) Label() string {
+	if b.Help != "" {
+		return b.Help
+	}
+	if len(b.Keys) > 0 {
+		return b.Keys[0]
+	}
+	return ""
+}
+
+// Enabled returns whether the binding is enabled.
+func (b KeyBinding) Enabled() bool {
+	return !b.Disabled && len(b.Keys) > 0
+}
+
+// Matches returns whether the key message triggers one of the enabled
+// bindings.
+func Matches(msg tea.KeyMsg, bindings ...KeyBinding) bool {
+	for _, b := range bindings {
+		if b.Enabled() && slices.Contains(b.Keys, msg.String()) {
+			return true
+		}
+	}
+	return false
+}
+
+// KeyBinder is an optional interface of CommonModel implemented by models
+// declaring their key bindings. Declared bindings are listed by the root
+// model help overlay, checked for conflicts and rendered as the default model
+// footer, see ViewFooter. Models taking text input declare none while
+// editing.
+type KeyBinder interface {
+	KeyBindings() []KeyBinding
+}
+
+// ModelBindings are the active key bindings declared by a model.
+type ModelBindings struct {
+	ModelID  string
+	Bindings []KeyBinding
+}
+
+// KeyConflict reports a key bound by more than one active binding.
+type KeyConflict struct {
+	Key      string
+	ModelIDs []string
+}
+
+// ActiveBindings returns, in tree order, the enabled key bindings of the
+// models under app: global bindings of all models and focus bindings of the
+// models on the focus path. The app model is always considered on the focus
+// path.
+func ActiveBindings(app CommonModel, focusPath []string) []ModelBindings {
+	var active []ModelBindings
+	var collect func(model CommonModel, onPath bool)
+	collect = func(model CommonModel, onPath bool) {
+		if binder, ok := model.(KeyBinder); ok && !model.IsDisabled() {
+			var bindings []KeyBinding
+			for _, b := range binder.KeyBindings() {
+				if b.Enabled() && (b.Scope == GlobalScope || onPath) {
+					bindings = append(bindings, b)
+				}
+			}
+			if len(bindings) > 0 {
+				active = append(active, ModelBindings{ModelID: model.GetModelID(), Bindings: bindings})
+			}
+		}
+		if ranger, ok := model.(modelRanger); ok {
+			ranger.RangeModels(func(child CommonModel) bool {
+				collect(child, slices.Contains(focusPath, child.GetModelID()))
+				return true
+			})
+		}
+	}
+	collect(app, true)
+	return active
+}
+
+// KeyConflicts returns the keys bound more than once among the active
+// bindings, sorted by key.
+func KeyConflicts(active []ModelBindings) []KeyConflict {
+	owners := make(map[string][]string)
+	for _, mb := range active {
+		for _, b := range mb.Bindings {
+			for _, k := range b.Keys {
+				owners[k] = append(owners[k], mb.ModelID)
+			}
+		}
+	}
+
+	var conflicts []KeyConflict
+	for k, ids := range owners {
+		if len(ids) > 1 {
+			conflicts = append(conflicts, KeyConflict{Key: k, ModelIDs: ids})
+		}
+	}
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i].Key < conflicts[j].Key })
+	return conflicts
+}
+
+// ShortHelp renders the bindings on a single line, e.g., "esc quit • f1 home",
+// for use in model footers.
+func ShortHelp(theme Themer, bindings []KeyBinding) string {
+	var items []string
+	for _, b := range bindings {
+		if !b.Enabled() || b.Desc == "" {
+			continue
+		}
+		items = append(items, theme.RenderPrimaryText(b.Label())+" "+theme.RenderSecondaryText(b.Desc))
+	}
+	return strings.Join(items, theme.RenderSecondaryText(" • "))
+}
+
+// ViewFooter returns the footer of the model: its view footer, or the short
+// help of its declared key bindings when it renders none.
+func ViewFooter(model CommonModel, w, h int) string {
+	if footer := model.GetViewFooter(w, h); footer != "" {
+		return footer
+	}
+	binder, ok := model.(KeyBinder)
+	if !ok {
+		return ""
+	}
+	theme := DefaultMinimalTheme()
+	if t, ok := model.(interface{ GetTheme() Themer }); ok {
+		theme = t.GetTheme()
+	}
+	return ShortHelp(theme, binder.KeyBindings())
+}
@@ -0,0 +1,365 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Direction is the axis along which a layout stacks its items.
+type Direction int
+
+const (
+	// Vertical stacks items from top to bottom.
+	Vertical Direction = iota
+
+	// Horizontal stacks items from left to right.
+	Horizontal
+)
+
+func (d Direction) String() string {
+	switch d {
+	case Vertical:
+		return "VERTICAL"
+	case Horizontal:
+		return "HORIZONTAL"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// SizeKind is how a layout item size is computed along the layout axis.
+type SizeKind int
+
+const (
+	// FixedSize items take exactly Size.Value cells.
+	FixedSize SizeKind = iota
+
+	// PercentSize items take Size.Value percent of the layout inner space.
+	PercentSize
+
+	// FlexSize items share the space left by fixed and percent items,
+	// proportionally to their Size.Value weight.
+	FlexSize
+)
+
+// Size is the size of a layout item along the layout axis.
+type Size struct {
+	Kind  SizeKind
+	Value int
+}
+
+// Fixed returns a size of n cells.
+func Fixed(n int) Size { return Size{Kind: FixedSize, Value: n} }
+
+// Percent returns a size of p percent of the layout inner space.
+func Percent(p int) Size { return Size{Kind: PercentSize, Value: p} }
+
+// Flex returns a flexible size of the specified weight.
+func Flex(weight int) Size { return Size{Kind: FlexSize, Value: weight} }
+
+// Padding is the number of blank cells around a layout content.
+type Padding struct {
+	Top, Right, Bottom, Left int
+}
+
+// Rect is a rectangle of cells, its origin being the top left corner.
+type Rect struct {
+	X, Y, Width, Height int
+}
+
+// Contains returns whether the cell at x, y is inside the rectangle.
+func (r Rect) Contains(x, y int) bool {
+	return x >= r.X && x < r.X+r.Width && y >= r.Y && y < r.Y+r.Height
+}
+
+// LayoutItem is one element of a layout. It is rendered by exactly one of
+// Model, Render or Layout, in that order of precedence.
+type LayoutItem struct {
+	// The model rendered with View(w, h) in the item rectangle. Hidden models
+	// take no space.
+	Model CommonModel
+
+	// A render function called with the item rectangle size, for content
+	// that isn't a model like window bars.
+	Render func(w, h int) string
+
+	// A nested layout rendered in the item rectangle.
+	Layout *Layout
+
+	// The item ID used in regions and overflow reports. Defaults to the
+	// model ID for model items.
+	ID string
+
+	// The item size along the layout axis, and its bounds. A zero Max means
+	// unbounded.
+	Size     Size
+	Min, Max int
+}
+
+// hidden returns whether the item is a model with the Hidden property, in
+// which case it takes no space and isn't rendered.
+func (it LayoutItem) hidden() bool {
+	return it.Model != nil && it.Model.GetProperties().Has(Hidden)
+}
+
+// id returns the item ID used in reports.
+func (it LayoutItem) id() string {
+	if it.ID == "" && it.Model != nil {
+		return it.Model.GetModelID()
+	}
+	return it.ID
+}
+
+// Layout stacks items vertically or horizontally. Each item gets a
+// rectangle along the layout axis according to its size constraints, and
+// the whole layout inner space on the cross axis.
+type Layout struct {
+	Direction Direction
+	Padding   Padding
+	Items     []LayoutItem
+}
+
+// VStack returns a vertical layout of the items.
+func VStack(items ...LayoutItem) *Layout {
+	return &Layout{Direction: Vertical, Items: items}
+}
+
+// HStack returns a horizontal layout of the items.
+func HStack(items ...LayoutItem) *Layout {
+	return &Layout{Direction: Horizontal, Items: items}
+}
+
+// Region is the rectangle an item was allocated, relative to the layout
+// origin.
+type Region struct {
+	ID string
+	Rect
+}
+
+// Overflow reports an item whose rendered content didn't fit its allocated
+// rectangle and was truncated.
+type Overflow struct {
+	ID string
+
+	// The allocated rectangle, relative to the layout origin.
+	Rect
+
+	// The rendered content size.
+	ContentWidth  int
+	ContentHeight int
+}
+
+// LayoutResult is the outcome of rendering a layout.
+type LayoutResult struct {
+	// The rendered view, exactly the requested size.
+	View string
+
+	// The rectangles allocated to every item, nested layouts included.
+	Regions []Region
+
+	// The items that overflowed their rectangle.
+	Overflows []Overflow
+}
+
+// Allocate returns the rectangles allocated to the layout items in a w by h
+// window, relative to the layout origin.
+func (l *Layout) Allocate(w, h int) []Rect {
+	p := l.Padding
+	innerW := max(w-p.Left-p.Right, 0)
+	innerH := max(h-p.Top-p.Bottom, 0)
+
+	main, cross := innerH, innerW
+	if l.Direction == Horizontal {
+		main, cross = innerW, innerH
+	}
+
+	sizes := allocate(l.Items, main)
+	rects := make([]Rect, len(l.Items))
+	offset := 0
+	for i, size := range sizes {
+		if l.Direction == Horizontal {
+			rects[i] = Rect{X: p.Left + offset, Y: p.Top, Width: size, Height: cross}
+		} else {
+			rects[i] = Rect{X: p.Left, Y: p.Top + offset, Width: cross, Height: size}
+		}
+		offset += size
+	}
+	return rects
+}
+
+// Render renders the layout in a w by h window. Every model and render
+// function is called with the exact size of its allocated rectangle. Content
+// that doesn't fit is truncated and reported in the result overflows.
+func (l *Layout) Render(w, h int) LayoutResult {
+	var res LayoutResult
+	res.View = l.render(w, h, 0, 0, &res)
+	return res
+}
+
+// render renders the layout at the x, y offset, accumulating regions and
+// overflows in res.
+func (l *Layout) render(w, h, x, y int, res *LayoutResult) string {
+	rects := l.Allocate(w, h)
+	blocks := make([]string, len(l.Items))
+
+	for i, it := range l.Items {
+		if it.hidden() {
+			continue
+		}
+		r := rects[i]
+		abs := Rect{X: x + r.X, Y: y + r.Y, Width: r.Width, Height: r.Height}
+		res.Regions = append(res.Regions, Region{ID: it.id(), Rect: abs})
+
+		var content string
+		switch {
+		case it.Model != nil:
+			content = safeView(it.Model, r.Width, r.Height)
+		case it.Render != nil:
+			content = it.Render(r.Width, r.Height)
+		case it.Layout != nil:
+			content = it.Layout.render(r.Width, r.Height, abs.X, abs.Y, res)
+		}
+
+		if cw, ch := lipgloss.Width(content), lipgloss.Height(content); content != "" && (cw > r.Width || ch > r.Height) {
+			res.Overflows = append(res.Overflows, Overflow{ID: it.id(), Rect: abs, ContentWidth: cw, ContentHeight: ch})
+		}
+		blocks[i] = fitRect(content, r.Width, r.Height)
+	}
+
+	var inner string
+	if l.Direction == Horizontal {
+		inner = joinHorizontal(blocks)
+	} else {
+		inner = joinVertical(blocks)
+	}
+	p := l.Padding
+	return fitRect(padRect(inner, p), w, h)
+}
+
+// allocate distributes the main axis space between the items.
+func allocate(items []LayoutItem, space int) []int {
+	sizes := make([]int, len(items))
+	left := space
+
+	clamp := func(it LayoutItem, n int) int {
+		n = max(n, it.Min)
+		if it.Max > 0 {
+			n = min(n, it.Max)
+		}
+		return max(n, 0)
+	}
+
+	// Fixed and percent items first, in order, as long as there is space.
+	// Hidden items are frozen at a zero size.
+	weights := 0
+	frozen := make([]bool, len(items))
+	for i, it := range items {
+		if it.hidden() {
+			frozen[i] = true
+			continue
+		}
+		switch it.Size.Kind {
+		case FixedSize:
+			sizes[i] = min(clamp(it, it.Size.Value), max(left, 0))
+		case PercentSize:
+			sizes[i] = min(clamp(it, space*it.Size.Value/100), max(left, 0))
+		case FlexSize:
+			weights += max(it.Size.Value, 1)
+			continue
+		}
+		left -= sizes[i]
+	}
+
+	// Flex items share what's left. Items hitting their bounds are frozen
+	// and the remaining space is shared again between the others.
+	for weights > 0 {
+		share, rest, changed := left, left, false
+		for i, it := range items {
+			if it.Size.Kind != FlexSize || frozen[i] {
+				continue
+			}
+			n := share * max(it.Size.Value, 1) / weights
+			if c := clamp(it, n); c != n {
+				sizes[i], frozen[i], changed = min(c, max(rest, 0)), true, true
+				left -= sizes[i]
+				weights -= max(it.Size.Value, 1)
+				break
+			}
+			sizes[i] = n
+			rest -= n
+		}
+		if changed {
+			continue
+		}
+
+		// Give the rounding remainder to the last flex items.
+		for i := len(items) - 1; i >= 0 && rest > 0; i-- {
+			if items[i].Size.Kind == FlexSize && !frozen[i] {
+				sizes[i]++
+				rest--
+			}
+		}
+		break
+	}
+	return sizes
+}
+
+// fitRect truncates and pads s to exactly w columns by h lines.
+func fitRect(s string, w, h int) string {
+	if w <= 0 || h <= 0 {
+		return ""
+	}
+	lines := strings.Split(s, "\n")
+	if s == "" {
+		lines = nil
+	}
+	out := make([]string, h)
+	for i := range out {
+		var line string
+		if i < len(lines) {
+			line = ansi.Truncate(lines[i], w, "")
+		}
+		if lw := ansi.StringWidth(line); lw < w {
+			line += strings.Repeat(" ", w-lw)
+		}
+		out[i] = line
+	}
+	return strings.Join(out, "\n")
+}
+
+// padRect surrounds s with the padding blank cells.
+func padRect(s string, p Padding) string {
+	if p == (Padding{}) {
+		return s
+	}
+	return lipgloss.NewStyle().Padding(p.Top, p.Right, p.Bottom, p.Left).Render(s)
+}
+
+// joinVertical stacks the non-empty blocks.
+func joinVertical(blocks []string) string {
+	var nonEmpty []string
+	for _, b := range blocks {
+		if b != "" {
+			nonEmpty = append(nonEmpty, b)
+		}
+	}
+	return strings.Join(nonEmpty, "\n")
+}
+
+// joinHorizontal places the non-empty blocks side by side. Blocks are
+// expected to have the same height.
+func joinHorizontal(blocks []string) string {
+	var nonEmpty []string
+	for _, b := range blocks {
+		if b != "" {
+			nonEmpty = append(nonEmpty, b)
+		}
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, nonEmpty...)
+}
@@ -0,0 +1,165 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// fill returns a render function filling its rectangle with r.
+func fill(r string) func(w, h int) string {
+	return func(w, h int) string {
+		lines := make([]string, h)
+		for i := range lines {
+			lines[i] = strings.Repeat(r, w)
+		}
+		return strings.Join(lines, "\n")
+	}
+}
+
+func TestLayoutAllocate(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout *Layout
+		w, h   int
+		want   []Rect
+	}{
+		{
+			name: "fixed and flex",
+			layout: VStack(
+				LayoutItem{Size: Fixed(1)},
+				LayoutItem{Size: Flex(1)},
+				LayoutItem{Size: Fixed(2)},
+			),
+			w: 10, h: 10,
+			want: []Rect{{0, 0, 10, 1}, {0, 1, 10, 7}, {0, 8, 10, 2}},
+		},
+		{
+			name: "percent and weighted flex",
+			layout: HStack(
+				LayoutItem{Size: Percent(50)},
+				LayoutItem{Size: Flex(1)},
+				LayoutItem{Size: Flex(3)},
+			),
+			w: 20, h: 3,
+			want: []Rect{{0, 0, 10, 3}, {10, 0, 2, 3}, {12, 0, 8, 3}},
+		},
+		{
+			name: "flex bounds",
+			layout: VStack(
+				LayoutItem{Size: Flex(1), Max: 2},
+				LayoutItem{Size: Flex(1)},
+			),
+			w: 4, h: 10,
+			want: []Rect{{0, 0, 4, 2}, {0, 2, 4, 8}},
+		},
+		{
+			name: "fixed items past the space",
+			layout: VStack(
+				LayoutItem{Size: Fixed(3)},
+				LayoutItem{Size: Fixed(3)},
+			),
+			w: 4, h: 4,
+			want: []Rect{{0, 0, 4, 3}, {0, 3, 4, 1}},
+		},
+		{
+			name: "padding",
+			layout: &Layout{
+				Direction: Vertical,
+				Padding:   Padding{Top: 1, Right: 1, Bottom: 1, Left: 2},
+				Items:     []LayoutItem{{Size: Flex(1)}},
+			},
+			w: 10, h: 5,
+			want: []Rect{{2, 1, 7, 3}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.layout.Allocate(tt.w, tt.h); !slices.Equal(got, tt.want) {
+				t.Errorf("Allocate(%d, %d) = %v, want %v", tt.w, tt.h, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLayoutRender(t *testing.T) {
+	tests := []struct {
+		name      string
+		layout    *Layout
+		w, h      int
+		want      string
+		regions   []Region
+		overflows []string
+	}{
+		{
+			name: "vstack",
+			layout: VStack(
+				LayoutItem{ID: "header", Render: fill("h"), Size: Fixed(1)},
+				LayoutItem{ID: "body", Render: fill("b"), Size: Flex(1)},
+				LayoutItem{ID: "footer", Render: fill("f"), Size: Fixed(1)},
+			),
+			w: 3, h: 4,
+			want: "hhh\nbbb\nbbb\nfff",
+			regions: []Region{
+				{"header", Rect{0, 0, 3, 1}},
+				{"body", Rect{0, 1, 3, 2}},
+				{"footer", Rect{0, 3, 3, 1}},
+			},
+		},
+		{
+			name: "nested hstack",
+			layout: VStack(
+				LayoutItem{ID: "top", Render: fill("t"), Size: Fixed(1)},
+				LayoutItem{ID: "row", Size: Flex(1), Layout: HStack(
+					LayoutItem{ID: "left", Render: fill("l"), Size: Fixed(1)},
+					LayoutItem{ID: "right", Render: fill("r"), Size: Flex(1)},
+				)},
+			),
+			w: 3, h: 2,
+			want: "ttt\nlrr",
+			regions: []Region{
+				{"top", Rect{0, 0, 3, 1}},
+				{"row", Rect{0, 1, 3, 1}},
+				{"left", Rect{0, 1, 1, 1}},
+				{"right", Rect{1, 1, 2, 1}},
+			},
+		},
+		{
+			name: "overflow truncated and padded",
+			layout: VStack(
+				LayoutItem{ID: "wide", Render: func(int, int) string { return "overflowing" }, Size: Fixed(1)},
+				LayoutItem{ID: "short", Render: func(int, int) string { return "s" }, Size: Fixed(1)},
+			),
+			w: 4, h: 2,
+			want: "over\ns   ",
+			regions: []Region{
+				{"wide", Rect{0, 0, 4, 1}},
+				{"short", Rect{0, 1, 4, 1}},
+			},
+			overflows: []string{"wide"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := tt.layout.Render(tt.w, tt.h)
+			if res.View != tt.want {
+				t.Errorf("View = %q, want %q", res.View, tt.want)
+			}
+			if !slices.Equal(res.Regions, tt.regions) {
+				t.Errorf("Regions = %v, want %v", res.Regions, tt.regions)
+			}
+			var overflows []string
+			for _, o := range res.Overflows {
+				overflows = append(overflows, o.ID)
+			}
+			if fmt.Sprint(overflows) != fmt.Sprint(tt.overflows) {
+				t.Errorf("Overflows = %v, want %v", overflows, tt.overflows)
+			}
+		})
+	}
+}
@@ -40,6 +40,7 @@ type DefaultLeafModel struct {
 // an embeded DefaultLeafModel instead of completely overwriting the method.
 func (m DefaultLeafModel) Update(msg tea.Msg) (LeafModel, tea.Cmd) {
 	var cmds []tea.Cmd
+	m.scopeLogger()
 
 	switch msg := msg.(type) {
 	// When disabled requested, accept and mark this model as disabled.
@@ -56,6 +57,23 @@ func (m DefaultLeafModel) Update(msg tea.Msg) (LeafModel, tea.Cmd) {
 			}
 		}
 
+	// When a property is requested, set it on recipients and release the
+	// exclusive ones everywhere else.
+	case SetPropertyMsg:
+		m.setProperty(msg)
+
+	// When a property is added, set it.
+	case AddPropertyMsg:
+		if msg.IsRecipient(m.GetModelID()) {
+			m.updateProperty(msg, msg.Property, true)
+		}
+
+	// When a property removal is requested, unset it.
+	case UnsetPropertyMsg:
+		if msg.IsRecipient(m.GetModelID()) {
+			m.updateProperty(msg, msg.Property, false)
+		}
+
 	// When focus requested, accept and mark this model as focused.
 	case SetFocusMsg:
 		if msg.IsRecipient(m.GetModelID()) {
@@ -72,20 +90,37 @@ func (m DefaultLeafModel) Update(msg tea.Msg) (LeafModel, tea.Cmd) {
 
 	// ShuttingDownMsg means that the application is terminating: cleanup and inactivate.
 	case ShutDownMsg:
-		if msg.IsRecipient(m.GetModelID()) && !m.IsShuttingDown() {
-			m.State = ShuttingDownState
-			m.LogStateChange(msg)
+		if msg.IsRecipient(m.GetModelID()) && !m.IsShuttingDown() && !m.IsFinished() {
+			m.SetState(ShuttingDownState, msg)
 
 			m.CancelContext()
-			cmds = append(cmds, ModelFinishedCmd(m.GetModelID()))
+			cmds = append(cmds, m.modelFinishedCmd(DefaultShutdownDeadline))
 			m.LogAction(msg, "Requesting model finished")
 		}
 
+	// When a new theme is requested, use it from now on.
+	case SetThemeMsg:
+		if msg.IsRecipient(m.GetModelID()) {
+			m.Theme = msg.Theme
+			m.LogNotice(msg, "Theme changed")
+		}
+
+	// A recoverable error from the model itself.
+	case ErrMsg:
+		if msg.Severity == RecoverableSeverity && msg.SourceModelID == m.GetModelID() {
+			m.SetState(ErroredState, msg)
+		}
+
+	// The failed model retries.
+	case RetryMsg:
+		if msg.IsRecipient(m.GetModelID()) && m.IsErrored() {
+			m.SetState(ActiveState, msg)
+		}
+
 	// ModelFinishedMsg marks the end-of-life for the model instance.
 	case ModelFinishedMsg:
 		if msg.IsRecipient(m.GetModelID()) && !m.IsFinished() {
-			m.State = FinishedState
-			m.LogStateChange(msg)
+			m.SetState(FinishedState, msg)
 		}
 	}
 
@@ -0,0 +1,330 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	charmlog "github.com/charmbracelet/log"
+	"github.com/muesli/termenv"
+	"github.com/spf13/viper"
+)
+
+// Format is the format of the records written to a sink.
+type Format string
+
+// The possible sink formats
+const (
+	TextFormat   Format = "text"
+	JSONFormat   Format = "json"
+	LogfmtFormat Format = "logfmt"
+)
+
+const (
+	// ViperKey is the configuration key of the logger settings, see
+	// ConfigureViper.
+	ViperKey = "log"
+
+	// DefaultMaxSize is the size in megabytes past which log files are
+	// rotated, when their sink doesn't set it.
+	DefaultMaxSize = 10
+
+	// DefaultMaxFiles is the number of rotated files kept, when their sink
+	// doesn't set it.
+	DefaultMaxFiles = 5
+)
+
+// Sink is a destination of the log records.
+type Sink struct {
+	// The log file path, or "stderr" and "stdout" for the standard outputs.
+	// Defaults to DefaultPath().
+	Path string `mapstructure:"path" json:"path,omitempty"`
+
+	// The records format, text by default.
+	Format Format `mapstructure:"format" json:"format,omitempty"`
+
+	// The size in megabytes past which the log file is rotated, see
+	// DefaultMaxSize. A negative size disables rotation.
+	MaxSize int `mapstructure:"max_size" json:"max_size,omitempty"`
+
+	// The number of rotated files kept, see DefaultMaxFiles. A negative
+	// number keeps them all.
+	MaxFiles int `mapstructure:"max_files" json:"max_files,omitempty"`
+
+	// The number of days rotated files are kept, forever when zero.
+	MaxAge int `mapstructure:"max_age" json:"max_age,omitempty"`
+}
+
+// Config is the logger configuration. Records are written to all of the
+// sinks, a default file sink is used when empty.
+type Config struct {
+	Sinks []Sink `mapstructure:"sinks" json:"sinks,omitempty"`
+
+	// The global level, e.g., "debug" or "warn", kept as is when empty.
+	Level string `mapstructure:"level" json:"level,omitempty"`
+
+	// The per-model level overrides, keyed by model ID or type, see
+	// SetModelLevel.
+	ModelLevels map[string]string `mapstructure:"model_levels" json:"model_levels,omitempty"`
+}
+
+// DefaultPath returns the default log file path in the XDG state directory:
+// $XDG_STATE_HOME/<binfile>/<binfile>.log, $XDG_STATE_HOME defaulting to
+// $HOME/.local/state.
+func DefaultPath() string {
+	name := filepath.Base(os.Args[0])
+	dir := os.Getenv("XDG_STATE_HOME")
+	if dir == "" || !filepath.IsAbs(dir) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return filepath.Join(os.TempDir(), name+".log")
+		}
+		dir = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(dir, name, name+".log")
+}
+
+// Configure replaces the default logger sinks and sets the configured levels.
+// Loggers created before, including with attributes or groups, log to the new
+// sinks. On error, the current sinks and levels are kept.
+func Configure(config Config) error {
+	levels, err := config.levels()
+	if err != nil {
+		return err
+	}
+
+	sinks := config.Sinks
+	if len(sinks) == 0 {
+		sinks = []Sink{{}}
+	}
+
+	set := &sinkSet{}
+	for _, sink := range sinks {
+		h, out, err := sink.open()
+		if err != nil {
+			_ = set.close()
+			return fmt.Errorf("while opening log sink %q: %w", sink.Path, err)
+		}
+		set.handlers = append(set.handlers, h)
+		set.outputs = append(set.outputs, out)
+	}
+
+	if old := current.Swap(set); old != nil {
+		_ = old.close()
+	}
+	for key, l := range levels {
+		if key == "" {
+			SetLevel(l)
+		} else {
+			SetModelLevel(key, l)
+		}
+	}
+	return nil
+}
+
+// levels parses the configured levels, the global one keyed by "".
+func (c Config) levels() (map[string]slog.Level, error) {
+	levels := map[string]slog.Level{}
+	parse := func(key, text string) error {
+		var l slog.Level
+		if err := l.UnmarshalText([]byte(text)); err != nil {
+			return fmt.Errorf("while parsing log level %q: %w", text, err)
+		}
+		levels[key] = l
+		return nil
+	}
+
+	if c.Level != "" {
+		if err := parse("", c.Level); err != nil {
+			return nil, err
+		}
+	}
+	for key, text := range c.ModelLevels {
+		if err := parse(key, text); err != nil {
+			return nil, err
+		}
+	}
+	return levels, nil
+}
+
+// ConfigureViper configures the default logger with the settings under the
+// ViperKey configuration key, e.g., in a JSON file:
+//
+//	"log": {
+//	  "sinks": [{"path": "/tmp/app.log", "format": "json", "max_size": 5}],
+//	  "model_levels": {"configurator": "debug"}
+//	}
+func ConfigureViper(vpr *viper.Viper) error {
+	var config Config
+	if err := vpr.UnmarshalKey(ViperKey, &config); err != nil {
+		return fmt.Errorf("while decoding %q logger settings: %w", ViperKey, err)
+	}
+	return Configure(config)
+}
+
+// open opens the sink output and returns its handler.
+func (s Sink) open() (slog.Handler, io.Writer, error) {
+	var formatter charmlog.Formatter
+	switch s.Format {
+	case TextFormat, "":
+		formatter = charmlog.TextFormatter
+	case JSONFormat:
+		formatter = charmlog.JSONFormatter
+	case LogfmtFormat:
+		formatter = charmlog.LogfmtFormatter
+	default:
+		return nil, nil, fmt.Errorf("unknown log format %q", s.Format)
+	}
+
+	var out io.Writer
+	switch strings.ToLower(s.Path) {
+	case "stderr":
+		out = os.Stderr
+	case "stdout":
+		out = os.Stdout
+	default:
+		path := s.Path
+		if path == "" {
+			path = DefaultPath()
+		}
+		maxSize, maxFiles := s.MaxSize, s.MaxFiles
+		if maxSize == 0 {
+			maxSize = DefaultMaxSize
+		}
+		if maxFiles == 0 {
+			maxFiles = DefaultMaxFiles
+		}
+		f, err := openRotatingFile(path, int64(max(maxSize, 0))<<20, max(maxFiles, 0), time.Duration(s.MaxAge)*24*time.Hour)
+		if err != nil {
+			return nil, nil, err
+		}
+		out = f
+	}
+
+	// The sinks log everything, the default logger filters the levels.
+	h := charmlog.NewWithOptions(out, charmlog.Options{
+		ReportCaller:    true,
+		ReportTimestamp: true,
+		TimeFormat:      time.StampMicro,
+		Formatter:       formatter,
+		Level:           charmlog.DebugLevel,
+	})
+	h.SetColorProfile(termenv.TrueColor)
+	return h, out, nil
+}
+
+// sinkSet is a configuration of the default logger sinks.
+type sinkSet struct {
+	handlers []slog.Handler
+	outputs  []io.Writer
+}
+
+// names returns the names of the sinks outputs.
+func (s *sinkSet) names() []string {
+	var names []string
+	for _, out := range s.outputs {
+		if named, ok := out.(interface{ Name() string }); ok {
+			names = append(names, named.Name())
+		}
+	}
+	return names
+}
+
+// close closes the sinks log files.
+func (s *sinkSet) close() error {
+	var errs []error
+	for _, out := range s.outputs {
+		if f, ok := out.(*rotatingFile); ok {
+			errs = append(errs, f.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// current is the current configuration of the default logger sinks.
+var current atomic.Pointer[sinkSet]
+
+// sinkHandler passes the records to the current sinks handlers. The
+// attributes and groups of derived handlers are applied to the sinks handlers
+// once per sinks configuration, so that derived loggers follow the sinks
+// configuration.
+type sinkHandler struct {
+	derive []func(slog.Handler) slog.Handler
+
+	// The sinks handlers derived for the last sinks configuration.
+	derived atomic.Pointer[derivedSinks]
+}
+
+// derivedSinks are the sinks handlers of a configuration, with the
+// attributes and groups of a derived sinkHandler applied.
+type derivedSinks struct {
+	set      *sinkSet
+	handlers []slog.Handler
+}
+
+// Enabled implements slog.Handler. The default logger filters the levels.
+func (h *sinkHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+// Handle implements slog.Handler.
+func (h *sinkHandler) Handle(ctx context.Context, r slog.Record) error {
+	set := current.Load()
+	if set == nil {
+		return nil
+	}
+	var errs []error
+	for _, sh := range h.handlers(set) {
+		errs = append(errs, sh.Handle(ctx, r.Clone()))
+	}
+	return errors.Join(errs...)
+}
+
+// handlers returns the sinks handlers of the configuration, derived on the
+// first record handled with it.
+func (h *sinkHandler) handlers(set *sinkSet) []slog.Handler {
+	if len(h.derive) == 0 {
+		return set.handlers
+	}
+	if d := h.derived.Load(); d != nil && d.set == set {
+		return d.handlers
+	}
+	d := &derivedSinks{set: set, handlers: make([]slog.Handler, len(set.handlers))}
+	for i, sh := range set.handlers {
+		for _, derive := range h.derive {
+			sh = derive(sh)
+		}
+		d.handlers[i] = sh
+	}
+	h.derived.Store(d)
+	return d.handlers
+}
+
+// WithAttrs implements slog.Handler.
+func (h *sinkHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.with(func(sh slog.Handler) slog.Handler { return sh.WithAttrs(attrs) })
+}
+
+// WithGroup implements slog.Handler.
+func (h *sinkHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return h.with(func(sh slog.Handler) slog.Handler { return sh.WithGroup(name) })
+}
+
+// with returns a derived handler.
+func (h *sinkHandler) with(derive func(slog.Handler) slog.Handler) *sinkHandler {
+	return &sinkHandler{derive: append(h.derive[:len(h.derive):len(h.derive)], derive)}
+}
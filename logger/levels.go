@@ -0,0 +1,179 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+var (
+	// The global level of the default logger.
+	level slog.LevelVar
+
+	// The per-model level overrides, keyed by model ID or type.
+	modelLevelsMu sync.RWMutex
+	modelLevels   = map[string]slog.Level{}
+
+	// The levels cycled through by NextLevel.
+	cycledLevels = []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelWarn, slog.LevelError}
+)
+
+// Level returns the global level of the default logger.
+func Level() slog.Level {
+	return level.Level()
+}
+
+// SetLevel sets the global level of the default logger.
+func SetLevel(l slog.Level) {
+	level.Set(l)
+}
+
+// NextLevel returns the level following l in the Debug, Info, Warn and Error
+// cycle, Debug for other levels.
+func NextLevel(l slog.Level) slog.Level {
+	i := slices.Index(cycledLevels, l)
+	return cycledLevels[(i+1)%len(cycledLevels)]
+}
+
+// SetModelLevel overrides the global level for the records of the models
+// matching key: a model ID, e.g., "configurator-1", or a model type, the ID
+// without its instance number, e.g., "configurator". Model records are those
+// with a ModelID attribute. ID overrides take precedence over type ones.
+func SetModelLevel(key string, l slog.Level) {
+	modelLevelsMu.Lock()
+	defer modelLevelsMu.Unlock()
+	modelLevels[key] = l
+}
+
+// ResetModelLevel removes the level override of key.
+func ResetModelLevel(key string) {
+	modelLevelsMu.Lock()
+	defer modelLevelsMu.Unlock()
+	delete(modelLevels, key)
+}
+
+// ModelLevels returns the per-model level overrides.
+func ModelLevels() map[string]slog.Level {
+	modelLevelsMu.RLock()
+	defer modelLevelsMu.RUnlock()
+	return maps.Clone(modelLevels)
+}
+
+// Levels controls the levels of the default logger, e.g., on behalf of the
+// bubbletree root model, see bubbletree.WithLevelController.
+type Levels struct{}
+
+// Level returns the global level of the default logger.
+func (Levels) Level() slog.Level {
+	return Level()
+}
+
+// SetLevel sets the global level of the default logger.
+func (Levels) SetLevel(l slog.Level) {
+	SetLevel(l)
+}
+
+// SetModelLevel overrides the global level for the models matching key, see
+// SetModelLevel.
+func (Levels) SetModelLevel(key string, l slog.Level) {
+	SetModelLevel(key, l)
+}
+
+// ResetModelLevel removes the level override of key.
+func (Levels) ResetModelLevel(key string) {
+	ResetModelLevel(key)
+}
+
+// levelFor returns the level of the records of the model identified by id,
+// the global level when it has no override.
+func levelFor(id string) slog.Level {
+	if id == "" {
+		return level.Level()
+	}
+	modelLevelsMu.RLock()
+	defer modelLevelsMu.RUnlock()
+
+	if l, ok := modelLevels[id]; ok {
+		return l
+	}
+	if i := strings.LastIndexByte(id, '-'); i > 0 {
+		if l, ok := modelLevels[id[:i]]; ok {
+			return l
+		}
+	}
+	return level.Level()
+}
+
+// minLevel returns the lowest of the global and overridden levels.
+func minLevel() slog.Level {
+	modelLevelsMu.RLock()
+	defer modelLevelsMu.RUnlock()
+
+	l := level.Level()
+	for _, ml := range modelLevels {
+		l = min(l, ml)
+	}
+	return l
+}
+
+// levelHandler filters the records by the global level, or by the level
+// override of the model they're from.
+type levelHandler struct {
+	next slog.Handler
+
+	// The ModelID attribute added with WithAttrs, if any.
+	modelID string
+}
+
+// Enabled implements slog.Handler. Without a known model, records at the
+// lowest overridden level are enabled, Handle filters them.
+func (h *levelHandler) Enabled(_ context.Context, l slog.Level) bool {
+	if h.modelID != "" {
+		return l >= levelFor(h.modelID)
+	}
+	return l >= minLevel()
+}
+
+// Handle implements slog.Handler.
+func (h *levelHandler) Handle(ctx context.Context, r slog.Record) error {
+	id := h.modelID
+	if id == "" {
+		r.Attrs(func(a slog.Attr) bool {
+			if a.Key == "ModelID" {
+				id = a.Value.String()
+				return false
+			}
+			return true
+		})
+	}
+	if r.Level < levelFor(id) {
+		return nil
+	}
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs implements slog.Handler.
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := &levelHandler{next: h.next.WithAttrs(attrs), modelID: h.modelID}
+	for _, a := range attrs {
+		if a.Key == "ModelID" {
+			clone.modelID = a.Value.String()
+		}
+	}
+	return clone
+}
+
+// WithGroup implements slog.Handler.
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &levelHandler{next: h.next.WithGroup(name), modelID: h.modelID}
+}
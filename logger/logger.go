@@ -7,16 +7,11 @@ package logger
 import (
 	"fmt"
 	"log/slog"
-	"os"
-	"path/filepath"
-	"time"
-
-	charmlog "github.com/charmbracelet/log"
-	"github.com/muesli/termenv"
+	"strings"
 )
 
-// Log returns a ready to use slog.Logger that has been initialized with a
-// charmbracelet logger handler.
+// Log returns a ready to use slog.Logger that has been initialized with
+// charmbracelet logger handlers, one per configured sink, see Configure.
 func Log() *slog.Logger {
 	return defaultLogger
 }
@@ -25,55 +20,56 @@ func Log() *slog.Logger {
 // the program runs in debug mode or not.
 func SetLoggerLevel(debug bool) *slog.Logger {
 	if debug {
-		handler.SetLevel(charmlog.DebugLevel)
+		level.Set(slog.LevelDebug)
 	} else {
-		handler.SetLevel(charmlog.InfoLevel)
+		level.Set(slog.LevelInfo)
 	}
 	return defaultLogger
 }
 
-// GetLoggerOutputName returns the current logger's output file name.
+// Ring returns the ring buffer handler keeping the last records of the
+// default logger, for in-app log viewers.
+func Ring() *RingHandler {
+	return ring
+}
+
+// GetLoggerOutputName returns the current logger's output file names.
 func GetLoggerOutputName() string {
-	return output.Name()
+	if set := current.Load(); set != nil {
+		return strings.Join(set.names(), ", ")
+	}
+	return ""
 }
 
-// CloseLoggerOutput closes the logger's output file when it's a disk file.
+// CloseLoggerOutput closes the logger's output files.
 func CloseLoggerOutput() error {
-	if output != os.Stderr {
-		return output.Close()
+	if set := current.Load(); set != nil {
+		return set.close()
 	}
 	return nil
 }
 
 var (
 	defaultLogger *slog.Logger
-	handler       *charmlog.Logger
-	output        *os.File
+	ring          *RingHandler
+)
+
+const (
+	// The number of records kept in memory by the default logger.
+	ringSize = 2000
 )
 
 // Initialize the default logger at init time, so that it's ready for caller
-// packages.
+// packages. It logs to the default file sink, or to stderr when the file
+// can't be opened, and keeps the last records in memory. The records are
+// filtered by level before both.
 func init() {
-	opts := charmlog.Options{
-		ReportCaller:    true,
-		ReportTimestamp: true,
-		TimeFormat:      time.StampMicro,
-		Formatter:       charmlog.TextFormatter,
-		Level:           charmlog.InfoLevel,
-	}
-	filename := filepath.Join("/", "var", "tmp", fmt.Sprintf("%s-%d.log", filepath.Base(os.Args[0]), os.Getpid()))
-	defaultLogger = loggerWithFile(filename, opts)
-}
+	level.Set(slog.LevelInfo)
+	ring = NewRingHandler(&sinkHandler{}, ringSize)
+	defaultLogger = slog.New(&levelHandler{next: ring})
 
-// loggerWithFile creates a new slog interfaced logger with a charm logger
-// handler. The logger writes logs to file: /var/tmp/<binfile>-<pid>.log.
-func loggerWithFile(filename string, o charmlog.Options) *slog.Logger {
-	var err error
-	output, err = os.OpenFile(filename, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o666)
-	if err != nil {
-		output = os.Stderr
+	if err := Configure(Config{}); err != nil {
+		_ = Configure(Config{Sinks: []Sink{{Path: "stderr"}}})
+		defaultLogger.Error(fmt.Sprintf("could not open default log file %s", DefaultPath()), "error", err)
 	}
-	handler = charmlog.NewWithOptions(output, o)
-	handler.SetColorProfile(termenv.TrueColor)
-	return slog.New(handler)
 }
@@ -0,0 +1,197 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Entry is a log record kept by a RingHandler.
+type Entry struct {
+	// The entry sequence number, increasing with each record.
+	Seq uint64
+
+	Time    time.Time
+	Level   slog.Level
+	Message string
+
+	// The value of the "ModelID" attribute, if any.
+	ModelID string
+
+	// The record attributes, group names prefixed to the keys.
+	Attrs []slog.Attr
+}
+
+// AttrsString returns the entry attributes formatted as key=value pairs.
+func (e Entry) AttrsString() string {
+	var b strings.Builder
+	for i, a := range e.Attrs {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		fmt.Fprintf(&b, "%s=%v", a.Key, a.Value)
+	}
+	return b.String()
+}
+
+// RingHandler is a slog.Handler keeping the last log records in memory, in a
+// fixed-size ring buffer, while teeing them into another handler. It lets
+// programs show their own logs, see Entries.
+type RingHandler struct {
+	next slog.Handler
+	buf  *ringBuffer
+
+	// The attributes and groups added with WithAttrs and WithGroup.
+	attrs  []slog.Attr
+	groups []string
+}
+
+// ringBuffer is the ring buffer shared by a RingHandler and its derived
+// handlers.
+type ringBuffer struct {
+	mu      sync.Mutex
+	entries []Entry
+
+	// The index of the next entry written, and whether the buffer wrapped.
+	head int
+	full bool
+
+	// The last entry sequence number.
+	seq uint64
+
+	// Signals new entries, coalesced.
+	updated chan struct{}
+}
+
+// NewRingHandler returns a new RingHandler keeping the last size records
+// handled, and passing them to next.
+func NewRingHandler(next slog.Handler, size int) *RingHandler {
+	return &RingHandler{
+		next: next,
+		buf: &ringBuffer{
+			entries: make([]Entry, max(size, 1)),
+			updated: make(chan struct{}, 1),
+		},
+	}
+}
+
+// Enabled implements slog.Handler, following the teed handler level.
+func (h *RingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler, recording the entry before passing the
+// record to the teed handler.
+func (h *RingHandler) Handle(ctx context.Context, r slog.Record) error {
+	e := Entry{Time: r.Time, Level: r.Level, Message: r.Message}
+	for _, a := range h.attrs {
+		e.add(nil, a)
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		e.add(h.groups, a)
+		return true
+	})
+	h.buf.add(e)
+
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs implements slog.Handler.
+func (h *RingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.next = h.next.WithAttrs(attrs)
+	clone.attrs = slices.Clone(h.attrs)
+	for _, a := range attrs {
+		if len(h.groups) > 0 {
+			a.Key = strings.Join(h.groups, ".") + "." + a.Key
+		}
+		clone.attrs = append(clone.attrs, a)
+	}
+	return &clone
+}
+
+// WithGroup implements slog.Handler.
+func (h *RingHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	clone := *h
+	clone.next = h.next.WithGroup(name)
+	clone.groups = append(slices.Clone(h.groups), name)
+	return &clone
+}
+
+// Entries returns the kept entries, oldest first.
+func (h *RingHandler) Entries() []Entry {
+	b := h.buf
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.full {
+		return slices.Clone(b.entries[:b.head])
+	}
+	return append(slices.Clone(b.entries[b.head:]), b.entries[:b.head]...)
+}
+
+// Seq returns the sequence number of the last entry.
+func (h *RingHandler) Seq() uint64 {
+	h.buf.mu.Lock()
+	defer h.buf.mu.Unlock()
+	return h.buf.seq
+}
+
+// Updated returns a channel signaled when new entries are recorded.
+// Notifications are coalesced, and shared by all of the channel readers.
+func (h *RingHandler) Updated() <-chan struct{} {
+	return h.buf.updated
+}
+
+// add records the entry in the ring buffer.
+func (b *ringBuffer) add(e Entry) {
+	b.mu.Lock()
+	b.seq++
+	e.Seq = b.seq
+	b.entries[b.head] = e
+	b.head = (b.head + 1) % len(b.entries)
+	if b.head == 0 {
+		b.full = true
+	}
+	b.mu.Unlock()
+
+	select {
+	case b.updated <- struct{}{}:
+	default:
+	}
+}
+
+// add adds the attribute to the entry, prefixing its key with the groups and
+// flattening group attributes.
+func (e *Entry) add(groups []string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			groups = append(slices.Clone(groups), a.Key)
+		}
+		for _, ga := range a.Value.Group() {
+			e.add(groups, ga)
+		}
+		return
+	}
+	if len(groups) > 0 {
+		a.Key = strings.Join(groups, ".") + "." + a.Key
+	} else if a.Key == "ModelID" {
+		e.ModelID = a.Value.String()
+	}
+	e.Attrs = append(e.Attrs, a)
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package logger
+
+import (
+	"io"
+	"log/slog"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestRingHandlerEntries(t *testing.T) {
+	tests := []struct {
+		name    string
+		size    int
+		records int
+		want    []string
+	}{
+		{"empty", 3, 0, nil},
+		{"partial", 3, 2, []string{"0", "1"}},
+		{"full", 3, 3, []string{"0", "1", "2"}},
+		{"wrapped", 3, 5, []string{"2", "3", "4"}},
+		{"minimum size", 0, 2, []string{"1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewRingHandler(slog.NewTextHandler(io.Discard, nil), tt.size)
+			l := slog.New(h)
+			for i := range tt.records {
+				l.Info(strconv.Itoa(i))
+			}
+
+			var got []string
+			for i, e := range h.Entries() {
+				got = append(got, e.Message)
+				if want := uint64(tt.records - len(h.Entries()) + i + 1); e.Seq != want {
+					t.Errorf("entry %q seq = %d, want %d", e.Message, e.Seq, want)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("entries = %v, want %v", got, tt.want)
+			}
+			if h.Seq() != uint64(tt.records) {
+				t.Errorf("seq = %d, want %d", h.Seq(), tt.records)
+			}
+		})
+	}
+}
+
+func TestRingHandlerAttrs(t *testing.T) {
+	tests := []struct {
+		name    string
+		log     func(l *slog.Logger)
+		attrs   string
+		modelID string
+	}{
+		{
+			name:  "record",
+			log:   func(l *slog.Logger) { l.Info("msg", "a", 1, "b", "x") },
+			attrs: "a=1 b=x",
+		},
+		{
+			name:    "scoped",
+			log:     func(l *slog.Logger) { l.With("ModelID", "pane-1").Info("msg", "a", 1) },
+			attrs:   "ModelID=pane-1 a=1",
+			modelID: "pane-1",
+		},
+		{
+			name:  "groups",
+			log:   func(l *slog.Logger) { l.WithGroup("g").With("a", 1).Info("msg", slog.Group("h", "b", 2)) },
+			attrs: "g.a=1 g.h.b=2",
+		},
+		{
+			name:  "grouped model ID",
+			log:   func(l *slog.Logger) { l.WithGroup("g").Info("msg", "ModelID", "pane-1") },
+			attrs: "g.ModelID=pane-1",
+		},
+		{
+			name:  "empty group",
+			log:   func(l *slog.Logger) { l.Info("msg", slog.Group("g"), "a", 1) },
+			attrs: "a=1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewRingHandler(slog.NewTextHandler(io.Discard, nil), 1)
+			tt.log(slog.New(h))
+
+			e := h.Entries()[0]
+			if got := e.AttrsString(); got != tt.attrs {
+				t.Errorf("attrs = %q, want %q", got, tt.attrs)
+			}
+			if e.ModelID != tt.modelID {
+				t.Errorf("model ID = %q, want %q", e.ModelID, tt.modelID)
+			}
+		})
+	}
+}
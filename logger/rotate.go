@@ -0,0 +1,140 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// rotatedTimeFormat is the suffix of rotated log files, sorting in rotation
+// order.
+const rotatedTimeFormat = "20060102T150405.000000000"
+
+// rotatingFile is a log file rotated when it grows past maxSize bytes. The
+// rotated files are renamed with a timestamp suffix, and the oldest are
+// removed past maxFiles files or maxAge. It is safe for concurrent use.
+type rotatingFile struct {
+	mu sync.Mutex
+
+	path     string
+	maxSize  int64
+	maxFiles int
+	maxAge   time.Duration
+
+	file *os.File
+	size int64
+}
+
+// openRotatingFile opens the log file for appending, creating it and its
+// directory if needed, and removes the expired rotated files.
+func openRotatingFile(path string, maxSize int64, maxFiles int, maxAge time.Duration) (*rotatingFile, error) {
+	f := &rotatingFile{path: path, maxSize: maxSize, maxFiles: maxFiles, maxAge: maxAge}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	f.prune()
+	return f, nil
+}
+
+// Name returns the log file path.
+func (f *rotatingFile) Name() string {
+	return f.path
+}
+
+// Write implements io.Writer, rotating the file first when p would make it
+// grow past its maximum size.
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+	if f.maxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Close implements io.Closer.
+func (f *rotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// open opens the log file for appending.
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	f.file, f.size = file, info.Size()
+	return nil
+}
+
+// rotate renames the current log file with a timestamp suffix and starts a
+// new one.
+func (f *rotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	f.file = nil
+	if err := os.Rename(f.path, f.path+"."+time.Now().Format(rotatedTimeFormat)); err != nil {
+		return err
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+	f.prune()
+	return nil
+}
+
+// prune removes the rotated files past the retention limits, oldest first.
+func (f *rotatingFile) prune() {
+	rotated, err := filepath.Glob(f.path + ".*")
+	if err != nil {
+		return
+	}
+	rotated = slices.DeleteFunc(rotated, func(name string) bool {
+		_, err := time.ParseInLocation(rotatedTimeFormat, strings.TrimPrefix(name, f.path+"."), time.Local)
+		return err != nil
+	})
+	slices.Sort(rotated)
+
+	for i, name := range rotated {
+		expired := f.maxFiles > 0 && i < len(rotated)-f.maxFiles
+		if !expired && f.maxAge > 0 {
+			stamp, _ := time.ParseInLocation(rotatedTimeFormat, strings.TrimPrefix(name, f.path+"."), time.Local)
+			expired = time.Since(stamp) > f.maxAge
+		}
+		if expired {
+			_ = os.Remove(name)
+		}
+	}
+}
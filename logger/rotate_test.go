@@ -0,0 +1,94 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestRotatingFilePrune(t *testing.T) {
+	now := time.Now()
+	rotated := func(age time.Duration) string {
+		return "app.log." + now.Add(-age).Format(rotatedTimeFormat)
+	}
+	files := []string{
+		rotated(3 * time.Hour),
+		rotated(2 * time.Hour),
+		rotated(time.Minute),
+		rotated(time.Second),
+		"app.log.bak",
+	}
+
+	tests := []struct {
+		name     string
+		maxFiles int
+		maxAge   time.Duration
+		want     []string
+	}{
+		{"unlimited", 0, 0, files},
+		{"max files", 2, 0, []string{files[2], files[3], files[4]}},
+		{"max age", 0, time.Hour, []string{files[2], files[3], files[4]}},
+		{"both", 1, time.Hour, []string{files[3], files[4]}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, name := range files {
+				if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			f := &rotatingFile{path: filepath.Join(dir, "app.log"), maxFiles: tt.maxFiles, maxAge: tt.maxAge}
+			f.prune()
+
+			left, err := filepath.Glob(filepath.Join(dir, "app.log.*"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := range left {
+				left[i] = filepath.Base(left[i])
+			}
+			want := slices.Sorted(slices.Values(tt.want))
+			if !slices.Equal(left, want) {
+				t.Errorf("files = %v, want %v", left, want)
+			}
+		})
+	}
+}
+
+func TestRotatingFileWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	f, err := openRotatingFile(path, 10, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	for _, line := range []string{"12345\n", "12345\n", "123\n", "1234567890ab\n"} {
+		if _, err := f.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "1234567890ab\n" {
+		t.Errorf("log file = %q, want the last write", data)
+	}
+	rotated, _ := filepath.Glob(path + ".*")
+	if len(rotated) != 1 {
+		t.Fatalf("rotated files = %v, want 1", rotated)
+	}
+	if data, _ := os.ReadFile(rotated[0]); string(data) != "12345\n123\n" {
+		t.Errorf("rotated file = %q, want the previous writes", data)
+	}
+}
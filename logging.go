@@ -0,0 +1,186 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// LogLevelKey is the root key binding cycling the global log level, see
+// CycleLogLevelCmd. It takes precedence over the tree models bindings. It is
+// only bound when the root model has a LevelController.
+var LogLevelKey = KeyBinding{Keys: []string{"ctrl+l"}, Desc: "log level", Scope: GlobalScope}
+
+// LevelController controls the log levels at runtime, on behalf of the root
+// model, see WithLevelController. The bubbletree/logger package Levels
+// controls the levels of its default logger.
+type LevelController interface {
+	// Level returns the global level.
+	Level() slog.Level
+
+	// SetLevel sets the global level.
+	SetLevel(level slog.Level)
+
+	// SetModelLevel overrides the global level for the records of the
+	// models matching key, a model ID or type.
+	SetModelLevel(key string, level slog.Level)
+
+	// ResetModelLevel removes the level override of key.
+	ResetModelLevel(key string)
+}
+
+// cycledLevels are the levels cycled through by CycleLogLevelCmd.
+var cycledLevels = []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelWarn, slog.LevelError}
+
+// ModelLogger returns the model's logger scoped with its ModelID attribute,
+// so that every record it logs is attributed to the model. It is the model's
+// Logger once scoped, a logger derived on each call before.
+func (m DefaultCommonModel) ModelLogger() *slog.Logger {
+	if m.Logger != nil && m.Logger == m.scoped {
+		return m.Logger
+	}
+	return m.GetLogger().With("ModelID", m.GetModelID())
+}
+
+// scopeLogger scopes the model's Logger with its ModelID attribute, unless
+// already done.
+func (m *DefaultCommonModel) scopeLogger() {
+	if m.Logger == nil || m.Logger == m.scoped {
+		return
+	}
+	m.unscoped = m.Logger
+	m.Logger = m.Logger.With("ModelID", m.GetModelID())
+	m.scoped = m.Logger
+}
+
+// unscopedLogger returns the model's logger without its ModelID attribute,
+// to log records about other models.
+func (m DefaultCommonModel) unscopedLogger() *slog.Logger {
+	if m.Logger != nil && m.Logger == m.scoped {
+		return m.unscoped
+	}
+	return m.GetLogger()
+}
+
+// modelLogger returns the logger scoped with the model ModelID attribute.
+func modelLogger(model CommonModel) *slog.Logger {
+	if l, ok := model.(interface{ ModelLogger() *slog.Logger }); ok {
+		return l.ModelLogger()
+	}
+	return slog.Default().With("ModelID", model.GetModelID())
+}
+
+// log logs a record with the model's scoped logger, through any handler. The
+// record caller is the caller of the function skip frames above log's caller,
+// so that the logging helpers report where they were called from.
+func (m DefaultCommonModel) log(skip int, level slog.Level, msg string, args ...any) {
+	logger, ctx := m.ModelLogger(), context.Background()
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+
+	// Skip runtime.Callers, log and its callers.
+	var pcs [1]uintptr
+	runtime.Callers(skip+2, pcs[:])
+	r := slog.NewRecord(time.Now(), level, msg, pcs[0])
+	r.Add(args...)
+	_ = logger.Handler().Handle(ctx, r)
+}
+
+// logStateChange logs the model state change, reporting the caller of the
+// function skip frames above.
+func (m DefaultCommonModel) logStateChange(skip int, msg any) {
+	m.log(skip+1, slog.LevelInfo, "Model State Change",
+		"NewState", m.State,
+		"OnMsg", fmt.Sprintf("%T", msg))
+}
+
+// LogStateChange sends a standardized log entry after a model state change.
+// SetState logs the changes it makes.
+func (m DefaultCommonModel) LogStateChange(msg any) {
+	m.logStateChange(1, msg)
+}
+
+// LogAction sends a standardized log entry after a new queued tea.Cmd.
+func (m DefaultCommonModel) LogAction(msg any, action string) {
+	m.log(1, slog.LevelInfo, "Model Action",
+		"Action", action,
+		"OnMsg", fmt.Sprintf("%T", msg))
+}
+
+// LogPropertyChange sends a standardized log entry after a model property
+// change.
+func (m DefaultCommonModel) LogPropertyChange(msg any, old, new Properties) {
+	m.log(1, slog.LevelInfo, "Model Property Change",
+		"Properties", fmt.Sprintf("%v -> %v", old, new),
+		"OnMsg", fmt.Sprintf("%T", msg))
+}
+
+// LogNotice sends a standardized log entry of a notification.
+func (m DefaultCommonModel) LogNotice(msg any, notice string) {
+	m.log(1, slog.LevelInfo, "Model Notification",
+		"Notice", notice,
+		"OnMsg", fmt.Sprintf("%T", msg))
+}
+
+// Msg definitions
+
+// SetLogLevelMsg changes the log levels at runtime: the global level when
+// Key is empty, or the level override of the models matching Key, a model ID
+// or type, see LevelController. Reset removes the override instead. It is
+// applied by the root model LevelController, if any, then passed down the
+// tree so that models displaying the levels can refresh.
+type SetLogLevelMsg struct {
+	Key   string
+	Level slog.Level
+	Reset bool
+}
+
+// Cmd definitions
+
+// SetLogLevelCmd returns a command setting the global log level when key is
+// empty, or the level of the models matching key.
+func SetLogLevelCmd(key string, level slog.Level) tea.Cmd {
+	return func() tea.Msg {
+		return SetLogLevelMsg{Key: key, Level: level}
+	}
+}
+
+// CycleLogLevelCmd returns a command setting the global log level of levels
+// to the next one of Debug, Info, Warn and Error.
+func CycleLogLevelCmd(levels LevelController) tea.Cmd {
+	i := slices.Index(cycledLevels, levels.Level())
+	return SetLogLevelCmd("", cycledLevels[(i+1)%len(cycledLevels)])
+}
+
+// ResetLogLevelCmd returns a command removing the level override of the
+// models matching key.
+func ResetLogLevelCmd(key string) tea.Cmd {
+	return func() tea.Msg {
+		return SetLogLevelMsg{Key: key, Reset: true}
+	}
+}
+
+// apply applies the level change with levels and returns its description.
+func (msg SetLogLevelMsg) apply(levels LevelController) string {
+	switch {
+	case msg.Key == "":
+		levels.SetLevel(msg.Level)
+		return fmt.Sprintf("Log level %s", msg.Level)
+	case msg.Reset:
+		levels.ResetModelLevel(msg.Key)
+		return fmt.Sprintf("Log level of %s reset to %s", msg.Key, levels.Level())
+	default:
+		levels.SetModelLevel(msg.Key, msg.Level)
+		return fmt.Sprintf("Log level of %s %s", msg.Key, msg.Level)
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestModelLoggerScoped(t *testing.T) {
+	var buf bytes.Buffer
+	leaf := newTestLeaf(&msgLog{}, "pane-1")
+	leaf.Logger = slog.New(slog.NewTextHandler(&buf, nil))
+
+	model, _ := leaf.Update(tea.WindowSizeMsg{Width: 10, Height: 2})
+	leaf = model.(testLeaf)
+	leaf.Logger.Info("direct")
+	leaf.ModelLogger().Info("scoped")
+	model, _ = leaf.Update(tea.WindowSizeMsg{Width: 10, Height: 2})
+	model.(testLeaf).ModelLogger().Info("updated")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("records = %q, want 3", lines)
+	}
+	for _, line := range lines {
+		if n := strings.Count(line, "ModelID=pane-1"); n != 1 {
+			t.Errorf("record %q has %d ModelID attributes, want 1", line, n)
+		}
+	}
+	if leaf.ModelLogger() != leaf.Logger {
+		t.Error("ModelLogger derived again once scoped")
+	}
+}
@@ -0,0 +1,436 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+// Package logview provides a log viewer LeafModel, showing the records kept
+// in memory by a logger.RingHandler. Any bubbletree program can use it to
+// show its own logs without leaving the TUI.
+package logview
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/yhcote/bubbletree"
+	"github.com/yhcote/bubbletree/logger"
+)
+
+const (
+	// short model name used for identification.
+	modelName = "logview"
+
+	// The number of lines moved by the page keys.
+	pageSize = 10
+
+	// The number of lines moved by a mouse wheel step.
+	wheelSize = 3
+
+	// How long bursts of records are coalesced into one refresh.
+	refreshInterval = 100 * time.Millisecond
+)
+
+var (
+	// unique model instance id based on 'modelName'.
+	lastID atomic.Int64
+
+	// The minimum levels cycled through by the level key.
+	levels = []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelWarn, slog.LevelError}
+)
+
+// keys are the model's key bindings, handled when the model is in focus.
+var keys = struct {
+	Up      bubbletree.KeyBinding
+	Down    bubbletree.KeyBinding
+	PgUp    bubbletree.KeyBinding
+	PgDown  bubbletree.KeyBinding
+	Top     bubbletree.KeyBinding
+	Tail    bubbletree.KeyBinding
+	Level   bubbletree.KeyBinding
+	Model   bubbletree.KeyBinding
+	Search  bubbletree.KeyBinding
+	Clear   bubbletree.KeyBinding
+	Verbose bubbletree.KeyBinding
+}{
+	Up:      bubbletree.KeyBinding{Keys: []string{"up", "k"}, Help: "↑/k", Desc: "up"},
+	Down:    bubbletree.KeyBinding{Keys: []string{"down", "j"}, Help: "↓/j", Desc: "down"},
+	PgUp:    bubbletree.KeyBinding{Keys: []string{"pgup"}, Desc: "page up"},
+	PgDown:  bubbletree.KeyBinding{Keys: []string{"pgdown"}, Desc: "page down"},
+	Top:     bubbletree.KeyBinding{Keys: []string{"home", "g"}, Help: "g", Desc: "top"},
+	Tail:    bubbletree.KeyBinding{Keys: []string{"end", "G"}, Help: "G", Desc: "live tail"},
+	Level:   bubbletree.KeyBinding{Keys: []string{"l"}, Desc: "level"},
+	Model:   bubbletree.KeyBinding{Keys: []string{"m"}, Desc: "model"},
+	Search:  bubbletree.KeyBinding{Keys: []string{"/"}, Desc: "search"},
+	Clear:   bubbletree.KeyBinding{Keys: []string{"x"}, Desc: "clear filters"},
+	Verbose: bubbletree.KeyBinding{Keys: []string{"v"}, Desc: "model debug"},
+}
+
+// New creates and initializes a new model ready to be used. It shows the
+// records of the default logger ring buffer, unless WithRing is passed.
+func New(opts ...Option) bubbletree.LeafModel {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	m := &Model{
+		DefaultLeafModel: bubbletree.DefaultLeafModel{
+			DefaultCommonModel: bubbletree.DefaultCommonModel{
+				ID:     fmt.Sprintf("%s-%d", modelName, lastID.Add(1)),
+				Ctx:    ctx,
+				Cancel: cancel,
+			},
+		},
+		ring:     logger.Ring(),
+		follow:   true,
+		minLevel: slog.LevelDebug,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	m.Logger.Info("New model created", "ModelID", m.ID)
+	return m
+}
+
+// Model is the log viewer model. It shows the ring buffer entries matching
+// its level, ModelID and search filters, following the new entries in live
+// tail mode, until scrolled up.
+type Model struct {
+	// Include fields and default methods of bubbletree.DefaultLeafModel.
+	bubbletree.DefaultLeafModel
+
+	// The ring buffer handler keeping the displayed records.
+	ring *logger.RingHandler
+
+	// Whether the view follows the new entries, otherwise anchor is the
+	// sequence number of the last entry shown.
+	follow bool
+	anchor uint64
+
+	// The filters: the minimum level, the ModelID attribute value, when
+	// set, and a case-insensitive search string.
+	minLevel slog.Level
+	modelID  string
+	query    string
+
+	// Whether the search string is being input, and the input so far.
+	editing bool
+	input   string
+}
+
+// Init starts waiting for new log entries.
+func (m Model) Init() tea.Cmd {
+	return m.waitEntries()
+}
+
+// Update is responsible for accepting a tea message passed down from the
+// parent model and update the model data when appropriate.
+func (m Model) Update(msg tea.Msg) (bubbletree.LeafModel, tea.Cmd) {
+	var (
+		cmds []tea.Cmd
+		ok   bool
+	)
+
+	// New entries were recorded, the view is refreshed, keep waiting even
+	// when disabled.
+	if _, ok := msg.(entriesMsg); ok {
+		return m, m.waitEntries()
+	}
+	if m.IsDisabled() {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	// Start showing logs with the program's first screen size report.
+	case tea.WindowSizeMsg:
+		if m.IsInactive() {
+			m.SetState(bubbletree.ActiveState, msg)
+		}
+
+	case tea.KeyMsg:
+		if m.editing {
+			cmds = append(cmds, m.updateSearch(msg))
+			break
+		}
+		switch {
+		case bubbletree.Matches(msg, keys.Up):
+			m.scroll(-1)
+		case bubbletree.Matches(msg, keys.Down):
+			m.scroll(1)
+		case bubbletree.Matches(msg, keys.PgUp):
+			m.scroll(-pageSize)
+		case bubbletree.Matches(msg, keys.PgDown):
+			m.scroll(pageSize)
+		case bubbletree.Matches(msg, keys.Top):
+			if entries := m.entries(); len(entries) > 0 {
+				m.follow, m.anchor = false, entries[0].Seq
+			}
+		case bubbletree.Matches(msg, keys.Tail):
+			m.follow = true
+		case bubbletree.Matches(msg, keys.Level):
+			m.minLevel = levels[(slices.Index(levels, m.minLevel)+1)%len(levels)]
+		case bubbletree.Matches(msg, keys.Model):
+			m.modelID = m.nextModelID()
+		case bubbletree.Matches(msg, keys.Search):
+			m.editing, m.input = true, m.query
+			// Capture all of the console input while typing.
+			cmds = append(cmds, bubbletree.AddPropertyCmd([]string{m.ID}, bubbletree.Modal))
+		case bubbletree.Matches(msg, keys.Clear):
+			m.minLevel, m.modelID, m.query = slog.LevelDebug, "", ""
+		case m.modelID != "" && bubbletree.Matches(msg, keys.Verbose):
+			// Toggle the debug level override of the filtered model.
+			if _, ok := logger.ModelLevels()[m.modelID]; ok {
+				cmds = append(cmds, bubbletree.ResetLogLevelCmd(m.modelID))
+			} else {
+				cmds = append(cmds, bubbletree.SetLogLevelCmd(m.modelID, slog.LevelDebug))
+			}
+		}
+
+	case tea.MouseMsg:
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			m.scroll(-wheelSize)
+		case tea.MouseButtonWheelDown:
+			m.scroll(wheelSize)
+		}
+	}
+
+	// Run the default message handlers from bubbletree.
+	leafModel, cmd := m.DefaultLeafModel.Update(msg)
+	if m.DefaultLeafModel, ok = leafModel.(bubbletree.DefaultLeafModel); !ok {
+		panic("DefaultLeafModel.Update didn't returned 'leadModel' as expected 'bubbletree.DefaultLeafModel' type")
+	}
+	cmds = append(cmds, cmd)
+
+	return m, tea.Batch(cmds...)
+}
+
+// updateSearch handles the search string input keys.
+func (m *Model) updateSearch(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.editing, m.query = false, m.input
+	case tea.KeyEsc:
+		m.editing = false
+	case tea.KeyBackspace:
+		if r := []rune(m.input); len(r) > 0 {
+			m.input = string(r[:len(r)-1])
+		}
+		return nil
+	case tea.KeyCtrlU:
+		m.input = ""
+		return nil
+	case tea.KeyRunes, tea.KeySpace:
+		m.input += string(msg.Runes)
+		return nil
+	default:
+		return nil
+	}
+	return bubbletree.UnsetPropertyCmd([]string{m.ID}, bubbletree.Modal)
+}
+
+// scroll moves the last entry shown by n entries, resuming the live tail
+// past the last entry.
+func (m *Model) scroll(n int) {
+	entries := m.entries()
+	if len(entries) == 0 {
+		return
+	}
+	i := m.last(entries) + n
+	if i >= len(entries)-1 {
+		m.follow = true
+		return
+	}
+	m.follow, m.anchor = false, entries[max(i, 0)].Seq
+}
+
+// last returns the index of the last entry shown.
+func (m Model) last(entries []logger.Entry) int {
+	if m.follow {
+		return len(entries) - 1
+	}
+	i, found := slices.BinarySearchFunc(entries, m.anchor, func(e logger.Entry, seq uint64) int {
+		return int(int64(e.Seq) - int64(seq))
+	})
+	if !found {
+		i--
+	}
+	return i
+}
+
+// entries returns the ring buffer entries matching the filters.
+func (m Model) entries() []logger.Entry {
+	if m.ring == nil {
+		return nil
+	}
+	query := strings.ToLower(m.query)
+	return slices.DeleteFunc(m.ring.Entries(), func(e logger.Entry) bool {
+		return e.Level < m.minLevel ||
+			(m.modelID != "" && e.ModelID != m.modelID) ||
+			(query != "" && !strings.Contains(strings.ToLower(e.Message+" "+e.AttrsString()), query))
+	})
+}
+
+// nextModelID returns the ModelID filter following the current one, in the
+// sorted list of the entries ModelID values, no filter coming first.
+func (m Model) nextModelID() string {
+	ids := []string{""}
+	if m.ring != nil {
+		for _, e := range m.ring.Entries() {
+			if e.ModelID != "" && !slices.Contains(ids, e.ModelID) {
+				ids = append(ids, e.ModelID)
+			}
+		}
+	}
+	slices.Sort(ids[1:])
+	return ids[(slices.Index(ids, m.modelID)+1)%len(ids)]
+}
+
+// waitEntries returns the command waiting for new entries to refresh the
+// view, until the model context is cancelled.
+func (m Model) waitEntries() tea.Cmd {
+	if m.ring == nil || m.Ctx == nil {
+		return nil
+	}
+	ring, ctx, id := m.ring, m.Ctx, m.ID
+	return func() tea.Msg {
+		select {
+		case <-ring.Updated():
+		case <-ctx.Done():
+			return nil
+		}
+		time.Sleep(refreshInterval)
+		return entriesMsg{modelID: id}
+	}
+}
+
+// View is the model's rendering routine that creates the output reflecting
+// the current state of the model data. The rendered string is passed back up
+// to the root model for final window composition.
+func (m Model) View(w, h int) string {
+	if !m.IsActive() || w <= 0 || h <= 0 {
+		return ""
+	}
+
+	var search string
+	if m.editing {
+		search = m.Theme.RenderPrimaryText("/"+m.input) + m.Theme.RenderSecondaryText("█")
+		h--
+	}
+
+	entries := m.entries()
+	end := 0
+	if len(entries) > 0 {
+		end = min(max(m.last(entries)+1, h), len(entries))
+	}
+	lines := make([]string, 0, h+1)
+	for _, e := range entries[max(end-h, 0):end] {
+		lines = append(lines, m.renderEntry(e, w))
+	}
+	if len(entries) == 0 {
+		lines = append(lines, m.Theme.RenderSecondaryText("No log entries"))
+	}
+	if m.editing {
+		for len(lines) < h {
+			lines = append(lines, "")
+		}
+		lines = append(lines, search)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderEntry renders a log entry on a w cells wide line.
+func (m Model) renderEntry(e logger.Entry, w int) string {
+	line := fmt.Sprintf("%s %-5s %s", e.Time.Format("15:04:05.000"), e.Level, e.Message)
+	if attrs := e.AttrsString(); attrs != "" {
+		line += " " + attrs
+	}
+	line = ansi.Truncate(line, w, "…")
+
+	switch {
+	case e.Level >= slog.LevelError:
+		return m.Theme.RenderErrorText(line)
+	case e.Level >= slog.LevelWarn:
+		return m.Theme.RenderPrimaryText(line)
+	case e.Level < slog.LevelInfo:
+		return m.Theme.RenderSecondaryText(line)
+	default:
+		return m.Theme.RenderNormalText(line)
+	}
+}
+
+// GetViewHeader returns the model's header view string, showing the active
+// filters.
+func (m Model) GetViewHeader(w, h int) string {
+	s := "Logs ≥" + m.minLevel.String()
+	if m.modelID != "" {
+		s += " • " + m.modelID
+		if l, ok := logger.ModelLevels()[m.modelID]; ok {
+			s += " (" + l.String() + ")"
+		}
+	}
+	if m.query != "" {
+		s += " • /" + m.query
+	}
+	if m.follow {
+		s += " • live"
+	}
+	return m.Theme.RenderNormalText(s)
+}
+
+// KeyBindings implements the bubbletree.KeyBinder interface, declaring the
+// model's key bindings.
+func (m Model) KeyBindings() []bubbletree.KeyBinding {
+	if m.editing {
+		return nil
+	}
+	verbose := keys.Verbose
+	verbose.Disabled = m.modelID == ""
+	return []bubbletree.KeyBinding{
+		keys.Up, keys.Down, keys.PgUp, keys.PgDown, keys.Top, keys.Tail,
+		keys.Level, keys.Model, keys.Search, keys.Clear, verbose,
+	}
+}
+
+// Msg/Cmd's
+
+// entriesMsg is an addressed message notifying the model of new log entries.
+type entriesMsg struct{ modelID string }
+
+// MsgRoute implements the Addressed interface.
+func (msg entriesMsg) MsgRoute() bubbletree.Route {
+	return bubbletree.Route{ModelIDs: []string{msg.modelID}}
+}
+
+// IsRecipient returns whether the message is destined to the specified model
+// instance.
+func (msg entriesMsg) IsRecipient(id string) bool {
+	return msg.modelID == id
+}
+
+// Options
+
+// Option is used to set options for the new model at creation.
+type Option func(*Model)
+
+func WithLogger(logger *slog.Logger) Option {
+	return func(m *Model) {
+		m.Logger = logger
+	}
+}
+
+func WithTheme(theme bubbletree.Themer) Option {
+	return func(m *Model) {
+		m.Theme = theme
+	}
+}
+
+// WithRing sets the ring buffer handler keeping the records shown.
+func WithRing(ring *logger.RingHandler) Option {
+	return func(m *Model) {
+		m.ring = ring
+	}
+}
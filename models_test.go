@@ -0,0 +1,145 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// msgLog records the messages received by the test models, by model ID.
+type msgLog struct {
+	mu   sync.Mutex
+	msgs map[string][]tea.Msg
+}
+
+// add records a message received by the model identified by id.
+func (l *msgLog) add(id string, msg tea.Msg) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.msgs == nil {
+		l.msgs = map[string][]tea.Msg{}
+	}
+	l.msgs[id] = append(l.msgs[id], msg)
+}
+
+// got returns the types of the messages received by the model identified by
+// id.
+func (l *msgLog) got(id string) []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var types []string
+	for _, msg := range l.msgs[id] {
+		types = append(types, fmt.Sprintf("%T", msg))
+	}
+	return types
+}
+
+// testCommon returns the common fields of a test model.
+func testCommon(id string) DefaultCommonModel {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	return DefaultCommonModel{
+		ID:     id,
+		Logger: slog.New(slog.DiscardHandler),
+		Ctx:    ctx,
+		Cancel: cancel,
+		Tasks:  NewTaskRunner(),
+	}
+}
+
+// testLeaf is a leaf model recording the messages it receives.
+type testLeaf struct {
+	DefaultLeafModel
+	log *msgLog
+}
+
+func newTestLeaf(log *msgLog, id string) testLeaf {
+	return testLeaf{DefaultLeafModel: DefaultLeafModel{DefaultCommonModel: testCommon(id)}, log: log}
+}
+
+func (m testLeaf) Update(msg tea.Msg) (LeafModel, tea.Cmd) {
+	m.log.add(m.ID, msg)
+	leaf, cmd := m.DefaultLeafModel.Update(msg)
+	m.DefaultLeafModel = leaf.(DefaultLeafModel)
+	return m, cmd
+}
+
+// testBranch is a branch model recording the messages it receives and
+// passing them down to its children.
+type testBranch struct {
+	DefaultBranchModel
+	log *msgLog
+}
+
+func newTestBranch(log *msgLog, id string, children ...CommonModel) testBranch {
+	m := testBranch{
+		DefaultBranchModel: DefaultBranchModel{DefaultCommonModel: testCommon(id), Models: NewRegistry()},
+		log:                log,
+	}
+	m.Models.SetUpdateMode(SequentialUpdate)
+	for _, child := range children {
+		var childID string
+		m.LinkNewModel(child, &childID)
+	}
+	return m
+}
+
+func (m testBranch) Init() tea.Cmd {
+	return m.InitNodeModels()
+}
+
+func (m testBranch) Update(msg tea.Msg) (BranchModel, tea.Cmd) {
+	m.log.add(m.ID, msg)
+	branch, cmd := m.DefaultBranchModel.Update(msg)
+	m.DefaultBranchModel = branch.(DefaultBranchModel)
+	return m, tea.Batch(cmd, m.UpdateNodeModels(msg))
+}
+
+// testApp is a core application model recording the messages it receives
+// and passing them down to its children. Its optional init command is run
+// along with its children's ones.
+type testApp struct {
+	DefaultAppModel
+	log  *msgLog
+	init func(m testApp) tea.Cmd
+}
+
+func newTestApp(log *msgLog, id string, children ...CommonModel) testApp {
+	branch := newTestBranch(log, id, children...)
+	return testApp{DefaultAppModel: DefaultAppModel{DefaultBranchModel: branch.DefaultBranchModel}, log: log}
+}
+
+func (m testApp) Init() tea.Cmd {
+	var cmd tea.Cmd
+	if m.init != nil {
+		cmd = m.init(m)
+	}
+	return tea.Batch(cmd, m.InitNodeModels())
+}
+
+func (m testApp) Update(msg tea.Msg) (BranchModel, tea.Cmd) {
+	m.log.add(m.ID, msg)
+	if size, ok := msg.(tea.WindowSizeMsg); ok {
+		m.Width, m.Height = size.Width, size.Height
+	}
+	branch, cmd := m.DefaultBranchModel.Update(msg)
+	m.DefaultBranchModel = branch.(DefaultBranchModel)
+	return m, tea.Batch(cmd, m.UpdateNodeModels(msg))
+}
+
+func (m testApp) View(w, h int) string {
+	return fmt.Sprintf("%s %dx%d", m.ID, w, h)
+}
+
+func (m testApp) AppView(quitting bool, err error) string {
+	if quitting {
+		return m.QuittingView(err)
+	}
+	return m.View(m.Width, m.Height)
+}
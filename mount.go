@@ -0,0 +1,167 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// mount links a new child model while the program runs: the child is added
+// to the registry, its Init() is run and it is sent the current window size
+// and the branch theme.
+func (m *DefaultBranchModel) mount(msg MountMsg) tea.Cmd {
+	id := msg.Model.GetModelID()
+	if _, ok := m.Models.Get(id); ok {
+		m.ModelLogger().Warn("Cannot mount model, ID already linked", "Child", id)
+		return nil
+	}
+	m.Models.StoreWithPriority(msg.Model, msg.Priority)
+	m.link(msg.Model)
+	m.LogAction(msg, "Mounted model "+id)
+
+	setup := []tea.Cmd{RouteCmd(tea.WindowSizeMsg{Width: msg.Width, Height: msg.Height}, id)}
+	if m.Theme != nil {
+		setup = append([]tea.Cmd{SetThemeCmd([]string{id}, m.Theme)}, setup...)
+	}
+	return tea.Batch(tea.Sequence(setup...), msg.Model.Init(), MountedCmd(m.GetModelID(), id))
+}
+
+// unmount starts the shutdown sequence of a child model, which is removed
+// from the registry once finished.
+func (m *DefaultBranchModel) unmount(msg UnmountMsg) tea.Cmd {
+	model, ok := m.Models.Get(msg.ModelID)
+	if !ok || slices.Contains(m.unmounting, msg.ModelID) {
+		return nil
+	}
+	if model.IsFinished() {
+		return m.unmounted(ModelFinishedMsg{ModelID: msg.ModelID})
+	}
+	m.unmounting = append(m.unmounting, msg.ModelID)
+	m.LogAction(msg, "Requesting shutdown of unmounted model "+msg.ModelID)
+	return ShutDownCmd([]string{msg.ModelID})
+}
+
+// unmounted removes a child model that finished its shutdown sequence
+// following an UnmountMsg.
+func (m *DefaultBranchModel) unmounted(msg ModelFinishedMsg) tea.Cmd {
+	m.unmounting = slices.DeleteFunc(m.unmounting, func(id string) bool { return id == msg.ModelID })
+	m.Models.Delete(msg.ModelID)
+	m.LogAction(msg, "Unmounted model "+msg.ModelID)
+	return UnmountedCmd(m.GetModelID(), msg.ModelID)
+}
+
+// isUnmounting returns whether the child model is being unmounted.
+func (m DefaultBranchModel) isUnmounting(id string) bool {
+	return slices.Contains(m.unmounting, id)
+}
+
+// Msg/Cmd's
+
+type (
+	// MountMsg is an addressed message requesting the branch model ParentID
+	// to link a new child model while the program runs. The root model fills
+	// in the current window size, sent to the new child along with the
+	// parent's theme after running its Init().
+	MountMsg struct {
+		ParentID string
+		Model    CommonModel
+
+		// The child position in the parent registry, see
+		// Registry.StoreWithPriority.
+		Priority int
+
+		// The current window size, filled in by the root model.
+		Screen
+	}
+
+	// MountedMsg is a model-global message sent once a child model was
+	// mounted.
+	MountedMsg struct{ ParentID, ModelID string }
+
+	// UnmountMsg is an addressed message requesting the removal of a child
+	// model while the program runs. The child runs its shutdown sequence,
+	// cancelling its context, and is removed from its parent registry once
+	// finished.
+	UnmountMsg struct{ ModelID string }
+
+	// UnmountedMsg is a model-global message sent once a child model was
+	// unmounted.
+	UnmountedMsg struct{ ParentID, ModelID string }
+
+	// SetThemeMsg is an addressed message sent to request that the listed
+	// model instances use the new theme.
+	SetThemeMsg struct {
+		ModelIDs []string
+		Theme    Themer
+	}
+)
+
+// MsgRoute implements the Addressed interface.
+func (msg MountMsg) MsgRoute() Route {
+	return Route{ModelIDs: []string{msg.ParentID}}
+}
+
+// IsRecipient returns whether the message is destined to the specified model
+// instance.
+func (msg MountMsg) IsRecipient(id string) bool {
+	return msg.ParentID == id
+}
+
+// MsgRoute implements the Addressed interface. The message goes down the
+// path to the unmounted model, through its parent.
+func (msg UnmountMsg) MsgRoute() Route {
+	return Route{ModelIDs: []string{msg.ModelID}}
+}
+
+// MsgRoute implements the Addressed interface.
+func (msg SetThemeMsg) MsgRoute() Route {
+	return Route{ModelIDs: msg.ModelIDs}
+}
+
+// IsRecipient returns whether the message is destined to the specified model
+// instance.
+func (msg SetThemeMsg) IsRecipient(id string) bool {
+	return slices.Contains(msg.ModelIDs, id)
+}
+
+// MountCmd returns a message requesting the branch model parentID to link
+// and start the new child model.
+func MountCmd(parentID string, model CommonModel) tea.Cmd {
+	return func() tea.Msg {
+		return MountMsg{ParentID: parentID, Model: model}
+	}
+}
+
+// MountedCmd returns a message reporting a mounted child model.
+func MountedCmd(parentID, id string) tea.Cmd {
+	return func() tea.Msg {
+		return MountedMsg{ParentID: parentID, ModelID: id}
+	}
+}
+
+// UnmountCmd returns a message requesting the shutdown and removal of the
+// model instance from its parent.
+func UnmountCmd(id string) tea.Cmd {
+	return func() tea.Msg {
+		return UnmountMsg{ModelID: id}
+	}
+}
+
+// UnmountedCmd returns a message reporting an unmounted child model.
+func UnmountedCmd(parentID, id string) tea.Cmd {
+	return func() tea.Msg {
+		return UnmountedMsg{ParentID: parentID, ModelID: id}
+	}
+}
+
+// SetThemeCmd returns a message requesting the model instances to use the
+// new theme.
+func SetThemeCmd(ids []string, theme Themer) tea.Cmd {
+	return func() tea.Msg {
+		return SetThemeMsg{ModelIDs: ids, Theme: theme}
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLinkedContext(t *testing.T) {
+	log := &msgLog{}
+	linked, deleted := newTestLeaf(log, "pane-1"), newTestLeaf(log, "pane-2")
+	tabs := newTestBranch(log, "tabs-1", linked, deleted)
+
+	tabs.Models.Delete("pane-2")
+	cause := errors.New("closed")
+	tabs.CancelContextCause(cause)
+
+	select {
+	case <-linked.Ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("linked child context not cancelled")
+	}
+	if got := context.Cause(linked.Ctx); got != cause {
+		t.Errorf("linked child cause = %v, want %v", got, cause)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if err := deleted.Ctx.Err(); err != nil {
+		t.Errorf("deleted child context = %v, want not cancelled", err)
+	}
+}
@@ -0,0 +1,305 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// regionMarkPrefix starts the zero-width region marks put in front of model
+// views, as OSC sequences terminated by BEL. The root model records the mark
+// positions in the composed screen and strips them before printing.
+const regionMarkPrefix = "\x1b]bubbletree;"
+
+// composing counts the root model views being composed with a MouseTracker.
+// Model views are only marked meanwhile, so that the views rendered outside
+// of a root model, e.g., in tests, don't carry the marks.
+var composing atomic.Int32
+
+// markRegion prefixes the model view with its region mark, carrying the
+// model ID and the w by h window size allocated to the view, while a root
+// model view is composed with a MouseTracker. The view size is used instead
+// for unconstrained (zero) dimensions.
+func markRegion(id, view string, w, h int) string {
+	if view == "" || composing.Load() == 0 {
+		return view
+	}
+	if w <= 0 {
+		w = lipgloss.Width(view)
+	}
+	if h <= 0 {
+		h = lipgloss.Height(view)
+	}
+	return fmt.Sprintf("%s%d;%d;%s\a", regionMarkPrefix, w, h, id) + view
+}
+
+// parseRegionMark decodes the "width;height;id" region mark payload.
+func parseRegionMark(s string) (Region, bool) {
+	var r Region
+	parts := strings.SplitN(s, ";", 3)
+	if len(parts) != 3 {
+		return r, false
+	}
+	if _, err := fmt.Sscan(parts[0], &r.Width); err != nil {
+		return r, false
+	}
+	if _, err := fmt.Sscan(parts[1], &r.Height); err != nil {
+		return r, false
+	}
+	r.ID = parts[2]
+	return r, true
+}
+
+// scanRegions strips the region marks from the view, returning the marked
+// regions in screen coordinates, in drawing order.
+func scanRegions(view string) (string, []Region) {
+	if !strings.Contains(view, regionMarkPrefix) {
+		return view, nil
+	}
+
+	var regions []Region
+	lines := strings.Split(view, "\n")
+	for y, line := range lines {
+		if !strings.Contains(line, regionMarkPrefix) {
+			continue
+		}
+		var b strings.Builder
+		for {
+			i := strings.Index(line, regionMarkPrefix)
+			if i < 0 {
+				b.WriteString(line)
+				break
+			}
+			b.WriteString(line[:i])
+			rest := line[i+len(regionMarkPrefix):]
+			j := strings.IndexByte(rest, '\a')
+			if j < 0 {
+				break
+			}
+			if r, ok := parseRegionMark(rest[:j]); ok {
+				r.X, r.Y = ansi.StringWidth(b.String()), y
+				regions = append(regions, r)
+			}
+			line = rest[j+1:]
+		}
+		lines[y] = b.String()
+	}
+	return strings.Join(lines, "\n"), regions
+}
+
+// MouseTracker records the screen rectangle each model view occupied during
+// the last root view composition, and routes the mouse events to the deepest
+// model under the cursor. It is owned by the root model and shared by
+// pointer between its copies.
+//
+// Mouse events are delivered in an Envelope to the target model, with the
+// coordinates translated to the model's local origin. The target is sent a
+// MouseEnterMsg when the cursor enters its region and the previous one a
+// MouseLeaveMsg. Mouse events are only reported by bubble tea programs
+// started with a mouse option, e.g., tea.WithMouseCellMotion().
+type MouseTracker struct {
+	// Whether a left click moves the focus to the clicked model, when it
+	// accepts the focus, see Focusable.
+	ClickToFocus bool
+
+	// The model regions of the last view, in drawing order, and the depth
+	// of their models in the tree.
+	regions []Region
+	depths  map[string]int
+
+	// The ID of the model under the cursor.
+	hovered string
+}
+
+// NewMouseTracker returns a new MouseTracker with click to focus enabled.
+func NewMouseTracker() *MouseTracker {
+	return &MouseTracker{
+		ClickToFocus: true,
+	}
+}
+
+// Regions returns the model regions of the last view, in drawing order.
+func (t *MouseTracker) Regions() []Region {
+	return slices.Clone(t.regions)
+}
+
+// Hovered returns the ID of the model under the cursor, if any.
+func (t *MouseTracker) Hovered() string {
+	return t.hovered
+}
+
+// RegionOf returns the region of the model identified by id in the last
+// view. Models drawn several times get their last region.
+func (t *MouseTracker) RegionOf(id string) (Region, bool) {
+	for i := len(t.regions) - 1; i >= 0; i-- {
+		if t.regions[i].ID == id {
+			return t.regions[i], true
+		}
+	}
+	return Region{}, false
+}
+
+// compose marks the model views with their regions until the returned
+// function is called, for the root model to record them. It does nothing for
+// a nil tracker.
+func (t *MouseTracker) compose() (done func()) {
+	if t == nil {
+		return func() {}
+	}
+	composing.Add(1)
+	return func() { composing.Add(-1) }
+}
+
+// record strips the region marks of the composed view, recording the model
+// regions and the depths of the tree models under app they belong to.
+func (t *MouseTracker) record(app CommonModel, view string) string {
+	view, regions := scanRegions(view)
+	if t == nil {
+		return view
+	}
+	t.regions = regions
+	t.depths = make(map[string]int, len(regions))
+	if len(regions) > 0 {
+		Walk(app, func(_ CommonModel, info NodeInfo) bool {
+			t.depths[info.ID] = info.Depth
+			return true
+		})
+	}
+	return view
+}
+
+// hit returns the region of the deepest tree model under the x, y cell. Of
+// models at the same depth, the last drawn wins.
+func (t *MouseTracker) hit(x, y int) (Region, bool) {
+	var (
+		found Region
+		depth = -1
+	)
+	for _, r := range t.regions {
+		if !r.Contains(x, y) {
+			continue
+		}
+		if d, ok := t.depths[r.ID]; ok && d >= depth {
+			found, depth = r, d
+		}
+	}
+	return found, depth >= 0
+}
+
+// hover moves the cursor over the model identified by id, returning the
+// leave and enter messages when it changed.
+func (t *MouseTracker) hover(id string) tea.Cmd {
+	if id == t.hovered {
+		return nil
+	}
+	var cmds []tea.Cmd
+	if t.hovered != "" {
+		cmds = append(cmds, MouseLeaveCmd(t.hovered))
+	}
+	if id != "" {
+		cmds = append(cmds, MouseEnterCmd(id))
+	}
+	t.hovered = id
+	return tea.Batch(cmds...)
+}
+
+// route returns the commands delivering the mouse event to the deepest tree
+// model under the cursor, or to the modal model when the cursor is outside
+// of it.
+func (t *MouseTracker) route(app CommonModel, modalID string, msg tea.MouseMsg) tea.Cmd {
+	target := Region{ID: app.GetModelID()}
+	if r, ok := t.hit(msg.X, msg.Y); ok {
+		target = r
+	}
+	if modalID != "" && target.ID != modalID {
+		if modal, ok := findModel(app, modalID); !ok || !isDescendant(modal, target.ID) {
+			target, _ = t.RegionOf(modalID)
+			target.ID = modalID
+		}
+	}
+
+	cmds := []tea.Cmd{t.hover(target.ID)}
+	if t.ClickToFocus && msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft {
+		if model, ok := findModel(app, target.ID); ok && !model.IsFocused() &&
+			model.GetProperties()&(Disabled|Hidden) == 0 {
+			if focusable, ok := model.(Focusable); ok && focusable.CanFocus() {
+				cmds = append(cmds, SetFocusCmd(target.ID))
+			}
+		}
+	}
+
+	local := msg
+	local.X, local.Y = msg.X-target.X, msg.Y-target.Y
+	cmds = append(cmds, RouteCmd(local, target.ID))
+	return tea.Sequence(cmds...)
+}
+
+// at returns the index of the topmost overlay under the x, y cell of a w by h
+// screen, or -1.
+func (s *OverlayStack) at(x, y, w, h int) int {
+	for i := s.Len() - 1; i >= 0; i-- {
+		if s.overlays[i].rect(w, h).Contains(x, y) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Msg/Cmd's
+
+type (
+	// MouseEnterMsg is an addressed message sent to a model when the mouse
+	// cursor enters its region.
+	MouseEnterMsg struct{ ModelID string }
+
+	// MouseLeaveMsg is an addressed message sent to a model when the mouse
+	// cursor leaves its region.
+	MouseLeaveMsg struct{ ModelID string }
+)
+
+// MsgRoute implements the Addressed interface.
+func (msg MouseEnterMsg) MsgRoute() Route {
+	return Route{ModelIDs: []string{msg.ModelID}}
+}
+
+// IsRecipient returns whether the message is destined to the specified model
+// instance.
+func (msg MouseEnterMsg) IsRecipient(id string) bool {
+	return msg.ModelID == id
+}
+
+// MsgRoute implements the Addressed interface.
+func (msg MouseLeaveMsg) MsgRoute() Route {
+	return Route{ModelIDs: []string{msg.ModelID}}
+}
+
+// IsRecipient returns whether the message is destined to the specified model
+// instance.
+func (msg MouseLeaveMsg) IsRecipient(id string) bool {
+	return msg.ModelID == id
+}
+
+// MouseEnterCmd returns a message notifying the model instance that the mouse
+// cursor entered its region.
+func MouseEnterCmd(id string) tea.Cmd {
+	return func() tea.Msg {
+		return MouseEnterMsg{ModelID: id}
+	}
+}
+
+// MouseLeaveCmd returns a message notifying the model instance that the mouse
+// cursor left its region.
+func MouseLeaveCmd(id string) tea.Cmd {
+	return func() tea.Msg {
+		return MouseLeaveMsg{ModelID: id}
+	}
+}
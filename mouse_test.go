@@ -0,0 +1,122 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestScanRegions(t *testing.T) {
+	defer NewMouseTracker().compose()()
+
+	tests := []struct {
+		name    string
+		view    string
+		want    string
+		regions []Region
+	}{
+		{
+			name: "unmarked",
+			view: "abc\ndef",
+			want: "abc\ndef",
+		},
+		{
+			name:    "measured",
+			view:    markRegion("pane-1", "ab\ncd", 0, 0),
+			want:    "ab\ncd",
+			regions: []Region{{ID: "pane-1", Rect: Rect{Width: 2, Height: 2}}},
+		},
+		{
+			name:    "allocated",
+			view:    markRegion("pane-1", "ab", 10, 3),
+			want:    "ab",
+			regions: []Region{{ID: "pane-1", Rect: Rect{Width: 10, Height: 3}}},
+		},
+		{
+			name: "nested and padded",
+			view: "title\n  " + markRegion("tabs-1", markRegion("pane-1", "ab", 4, 1)+" "+markRegion("pane-2", "cd", 4, 1), 9, 2),
+			want: "title\n  ab cd",
+			regions: []Region{
+				{ID: "tabs-1", Rect: Rect{X: 2, Y: 1, Width: 9, Height: 2}},
+				{ID: "pane-1", Rect: Rect{X: 2, Y: 1, Width: 4, Height: 1}},
+				{ID: "pane-2", Rect: Rect{X: 5, Y: 1, Width: 4, Height: 1}},
+			},
+		},
+		{
+			name: "empty view",
+			view: markRegion("pane-1", "", 4, 1),
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, regions := scanRegions(tt.view)
+			if got != tt.want {
+				t.Errorf("view = %q, want %q", got, tt.want)
+			}
+			if !slices.Equal(regions, tt.regions) {
+				t.Errorf("regions = %v, want %v", regions, tt.regions)
+			}
+		})
+	}
+}
+
+func TestMouseHit(t *testing.T) {
+	defer NewMouseTracker().compose()()
+
+	app := testTree(&msgLog{})
+	pane := markRegion("pane-1", "ab", 4, 2) + "    "
+	view := markRegion("app-1", markRegion("tabs-1", pane, 6, 3)+markRegion("status-1", "s", 2, 1), 8, 3)
+
+	mouse := NewMouseTracker()
+	mouse.record(app, view)
+
+	tests := []struct {
+		x, y int
+		want string
+	}{
+		{0, 0, "pane-1"},
+		{3, 1, "pane-1"},
+		{4, 1, "tabs-1"},
+		{6, 0, "status-1"},
+		{7, 2, "app-1"},
+		{9, 0, ""},
+	}
+	for _, tt := range tests {
+		r, _ := mouse.hit(tt.x, tt.y)
+		if r.ID != tt.want {
+			t.Errorf("hit(%d, %d) = %q, want %q", tt.x, tt.y, r.ID, tt.want)
+		}
+	}
+}
+
+func TestRegionMarks(t *testing.T) {
+	leaf := newTestLeaf(&msgLog{}, "pane-1")
+	leaf.State = ActiveState
+
+	tests := []struct {
+		name    string
+		tracker *MouseTracker
+		marked  bool
+	}{
+		{"outside of the root", nil, false},
+		{"root with a tracker", NewMouseTracker(), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			done := tt.tracker.compose()
+			view := safeView(leaf, 20, 1)
+			done()
+			if marked := strings.Contains(view, regionMarkPrefix); marked != tt.marked {
+				t.Errorf("view %q marked = %v, want %v", view, marked, tt.marked)
+			}
+			if view := safeView(leaf, 20, 1); strings.Contains(view, regionMarkPrefix) {
+				t.Errorf("view %q marked once composed", view)
+			}
+		})
+	}
+}
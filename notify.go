@@ -0,0 +1,287 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// NoticeLevel is the importance of a notification.
+type NoticeLevel int
+
+// The possible notification levels
+const (
+	InfoNotice NoticeLevel = iota
+	SuccessNotice
+	WarningNotice
+	ErrorNotice
+)
+
+func (l NoticeLevel) String() string {
+	switch l {
+	case InfoNotice:
+		return "INFO"
+	case SuccessNotice:
+		return "SUCCESS"
+	case WarningNotice:
+		return "WARNING"
+	case ErrorNotice:
+		return "ERROR"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// Corner is the screen corner where notification toasts are stacked.
+type Corner int
+
+// The possible toast corners
+const (
+	TopRightCorner Corner = iota
+	TopLeftCorner
+	BottomRightCorner
+	BottomLeftCorner
+)
+
+func (c Corner) String() string {
+	switch c {
+	case TopRightCorner:
+		return "TOPRIGHT"
+	case TopLeftCorner:
+		return "TOPLEFT"
+	case BottomRightCorner:
+		return "BOTTOMRIGHT"
+	case BottomLeftCorner:
+		return "BOTTOMLEFT"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+const (
+	// DefaultNoticeDuration is how long a toast is displayed when the
+	// notification doesn't set its duration.
+	DefaultNoticeDuration = 4 * time.Second
+
+	// noticeTickInterval is the period of the timer expiring the toasts.
+	noticeTickInterval = 250 * time.Millisecond
+)
+
+// Notification is a transient message displayed as a toast by the root
+// model, like "Settings saved" or "Connection lost".
+type Notification struct {
+	// The notification ID, set by the Notifier.
+	ID int
+
+	// The model instance sending the notification, if any.
+	SourceModelID string
+
+	Level NoticeLevel
+	Text  string
+
+	// How long the toast is displayed, DefaultNoticeDuration when zero.
+	Duration time.Duration
+
+	// The optional action key: pressing it while the toast is displayed
+	// dismisses the toast and runs OnAction.
+	Action   KeyBinding
+	OnAction tea.Cmd `json:"-"`
+
+	// When the notification was received by the root model.
+	Time time.Time
+}
+
+// toast is a displayed notification.
+type toast struct {
+	Notification
+	expires time.Time
+}
+
+// Notifier displays the notifications as toasts stacked in a corner of the
+// screen, the newest nearest the corner. All toasts are expired by a single
+// shared timer. It is held by the root model and shared by pointer between
+// its copies.
+type Notifier struct {
+	// The screen corner where toasts are stacked.
+	Corner Corner
+
+	// The maximum number of toasts displayed at once, the oldest are
+	// dropped first.
+	MaxToasts int
+
+	// The toasts width in cells, borders included.
+	Width int
+
+	// The last notification ID.
+	lastID int
+
+	// The displayed toasts, oldest first.
+	toasts []toast
+
+	// Whether the expiry timer is running.
+	ticking bool
+}
+
+// NewNotifier returns a new Notifier stacking up to 3 toasts in the top
+// right corner of the screen.
+func NewNotifier() *Notifier {
+	return &Notifier{
+		Corner:    TopRightCorner,
+		MaxToasts: 3,
+		Width:     40,
+	}
+}
+
+// Len returns the number of displayed toasts.
+func (n *Notifier) Len() int {
+	if n == nil {
+		return 0
+	}
+	return len(n.toasts)
+}
+
+// Toasts returns the displayed notifications, oldest first.
+func (n *Notifier) Toasts() []Notification {
+	if n == nil {
+		return nil
+	}
+	notes := make([]Notification, 0, len(n.toasts))
+	for _, t := range n.toasts {
+		notes = append(notes, t.Notification)
+	}
+	return notes
+}
+
+// push displays a new notification and returns it with its ID and time set,
+// along with the command starting the expiry timer, if not running.
+func (n *Notifier) push(note Notification) (Notification, tea.Cmd) {
+	n.lastID++
+	note.ID = n.lastID
+	note.Time = time.Now()
+	if note.Duration <= 0 {
+		note.Duration = DefaultNoticeDuration
+	}
+
+	n.toasts = append(n.toasts, toast{Notification: note, expires: note.Time.Add(note.Duration)})
+	if n.MaxToasts > 0 && len(n.toasts) > n.MaxToasts {
+		n.toasts = slices.Delete(n.toasts, 0, len(n.toasts)-n.MaxToasts)
+	}
+	return note, n.tick()
+}
+
+// tick returns the next expiry timer command, unless it's already running.
+func (n *Notifier) tick() tea.Cmd {
+	if n.ticking {
+		return nil
+	}
+	n.ticking = true
+	return tea.Tick(noticeTickInterval, func(t time.Time) tea.Msg {
+		return noticeTickMsg(t)
+	})
+}
+
+// expire removes the toasts expired at time now and returns the next expiry
+// timer command while toasts remain.
+func (n *Notifier) expire(now time.Time) tea.Cmd {
+	n.toasts = slices.DeleteFunc(n.toasts, func(t toast) bool { return !now.Before(t.expires) })
+	n.ticking = false
+	if len(n.toasts) == 0 {
+		return nil
+	}
+	return n.tick()
+}
+
+// action handles the action keys of the displayed toasts, newest first. It
+// returns whether msg was consumed, dismissing the toast, and the toast
+// action command.
+func (n *Notifier) action(msg tea.Msg) (bool, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || n.Len() == 0 {
+		return false, nil
+	}
+	for i := len(n.toasts) - 1; i >= 0; i-- {
+		if Matches(keyMsg, n.toasts[i].Action) {
+			cmd := n.toasts[i].OnAction
+			n.toasts = slices.Delete(n.toasts, i, i+1)
+			return true, cmd
+		}
+	}
+	return false, nil
+}
+
+// View draws the toasts over the base view of a w by h screen.
+func (n *Notifier) View(theme Themer, base string, w, h int) string {
+	view := fitRect(base, w, h)
+	if n.Len() == 0 {
+		return view
+	}
+
+	width := min(n.Width, w)
+	boxes := make([]string, 0, len(n.toasts))
+	for _, t := range n.toasts {
+		boxes = append(boxes, toastView(theme, t.Notification, width))
+	}
+	// The newest toast is the nearest to the corner.
+	if n.Corner == TopRightCorner || n.Corner == TopLeftCorner {
+		slices.Reverse(boxes)
+	}
+	stack := lipgloss.JoinVertical(lipgloss.Left, boxes...)
+
+	x, y := 0, 0
+	if n.Corner == TopRightCorner || n.Corner == BottomRightCorner {
+		x = w - width
+	}
+	if n.Corner == BottomRightCorner || n.Corner == BottomLeftCorner {
+		y = max(h-lipgloss.Height(stack), 0)
+	}
+	return placeOver(view, stack, x, y)
+}
+
+// toastView renders a notification toast, width cells wide.
+func toastView(theme Themer, note Notification, width int) string {
+	var s string
+	switch note.Level {
+	case SuccessNotice:
+		s = theme.RenderPrimaryText(note.Text)
+	case WarningNotice:
+		s = theme.RenderSecondaryText(note.Text)
+	case ErrorNotice:
+		s = theme.RenderErrorText(note.Text)
+	default:
+		s = theme.RenderNormalText(note.Text)
+	}
+	if note.Action.Enabled() {
+		s += "\n" + theme.RenderSecondaryText(note.Action.Label()+" "+note.Action.Desc)
+	}
+	return theme.GetBaseStyle().
+		Border(lipgloss.RoundedBorder()).
+		Padding(0, 1).
+		Width(max(width-2, 0)).
+		MaxWidth(width).
+		Render(s)
+}
+
+// Msg/Cmd's
+
+type (
+	// NotifyMsg is sent to the root model to display a notification toast.
+	// It is then passed down the model tree, with the notification ID and
+	// time set, so that models can keep a notification history.
+	NotifyMsg struct{ Notification Notification }
+
+	// noticeTickMsg is the toasts expiry timer tick.
+	noticeTickMsg time.Time
+)
+
+// NotifyCmd returns a message displaying the notification.
+func NotifyCmd(note Notification) tea.Cmd {
+	return func() tea.Msg {
+		return NotifyMsg{Notification: note}
+	}
+}
@@ -0,0 +1,272 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Placement is where an overlay is drawn on the screen.
+type Placement int
+
+const (
+	// CenterPlacement centers the overlay on the screen.
+	CenterPlacement Placement = iota
+
+	// AnchorPlacement draws the overlay with its top left corner at the
+	// overlay X, Y cell.
+	AnchorPlacement
+
+	// FullScreenPlacement draws the overlay over the whole screen.
+	FullScreenPlacement
+)
+
+func (p Placement) String() string {
+	switch p {
+	case CenterPlacement:
+		return "CENTER"
+	case AnchorPlacement:
+		return "ANCHOR"
+	case FullScreenPlacement:
+		return "FULLSCREEN"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// Overlay is a model drawn on top of the core application view, like a
+// confirmation dialog, a picker or an error popup. Overlay models live
+// outside of the model tree: the root model runs their Update() and View()
+// methods.
+type Overlay struct {
+	// The overlay model, a BranchModel or a LeafModel.
+	Model CommonModel
+
+	Placement Placement
+
+	// The top left corner of anchored overlays.
+	X, Y int
+
+	// The overlay size, half of the screen when zero. Ignored by full-screen
+	// overlays.
+	Width, Height int
+
+	// Whether the overlay captures all of the console input.
+	Modal bool
+
+	// Whether the screen under the overlay is dimmed.
+	Dim bool
+
+	// Stops the cancellation of the overlay model context along with the
+	// core application model one.
+	stop func() bool
+}
+
+// rect returns the overlay rectangle on a w by h screen.
+func (o Overlay) rect(w, h int) Rect {
+	if o.Placement == FullScreenPlacement {
+		return Rect{Width: w, Height: h}
+	}
+	r := Rect{X: o.X, Y: o.Y, Width: o.Width, Height: o.Height}
+	if r.Width <= 0 {
+		r.Width = w / 2
+	}
+	if r.Height <= 0 {
+		r.Height = h / 2
+	}
+	r.Width, r.Height = min(r.Width, w), min(r.Height, h)
+	if o.Placement == CenterPlacement {
+		r.X, r.Y = (w-r.Width)/2, (h-r.Height)/2
+	}
+	r.X, r.Y = min(max(r.X, 0), w-r.Width), min(max(r.Y, 0), h-r.Height)
+	return r
+}
+
+// OverlayStack is the stack of overlays drawn by the root model over the
+// core application view, the last pushed on top. It is shared by pointer
+// between the root model copies.
+type OverlayStack struct {
+	overlays []Overlay
+}
+
+// NewOverlayStack returns a new empty OverlayStack.
+func NewOverlayStack() *OverlayStack {
+	return &OverlayStack{}
+}
+
+// Len returns the number of overlays on the stack.
+func (s *OverlayStack) Len() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.overlays)
+}
+
+// Top returns the topmost overlay.
+func (s *OverlayStack) Top() (Overlay, bool) {
+	if s.Len() == 0 {
+		return Overlay{}, false
+	}
+	return s.overlays[len(s.overlays)-1], true
+}
+
+// push adds the overlay on top of the stack, linked like a model mounted
+// under the core application model: its context is cancelled along with the
+// parent context, and it is sent its window size on a w by h screen and the
+// theme after running its Init().
+func (s *OverlayStack) push(o Overlay, parent context.Context, theme Themer, w, h int) tea.Cmd {
+	o.stop = cascadeContext(parent, o.Model)
+	s.overlays = append(s.overlays, o)
+
+	id, r := o.Model.GetModelID(), o.rect(w, h)
+	setup := []tea.Cmd{RouteCmd(tea.WindowSizeMsg{Width: r.Width, Height: r.Height}, id)}
+	if theme != nil {
+		setup = append([]tea.Cmd{SetThemeCmd([]string{id}, theme)}, setup...)
+	}
+	return tea.Batch(tea.Sequence(setup...), o.Model.Init())
+}
+
+// pop removes the overlay of the model identified by id, or the topmost one
+// when id is empty, cancelling the overlay model context.
+func (s *OverlayStack) pop(id string) bool {
+	i := len(s.overlays) - 1
+	if id != "" {
+		i = slices.IndexFunc(s.overlays, func(o Overlay) bool { return o.Model.GetModelID() == id })
+	}
+	if i < 0 {
+		return false
+	}
+	o := s.overlays[i]
+	if o.stop != nil {
+		o.stop()
+	}
+	if contextOf(o.Model) != nil {
+		o.Model.CancelContext()
+	}
+	s.overlays = slices.Delete(s.overlays, i, i+1)
+	return true
+}
+
+// modal returns the index of the topmost modal overlay, or -1.
+func (s *OverlayStack) modal() int {
+	for i := len(s.overlays) - 1; i >= 0; i-- {
+		if s.overlays[i].Modal {
+			return i
+		}
+	}
+	return -1
+}
+
+// update runs the Update() method of the overlay model at index i. An
+// overlay panicking in Update() stops capturing the console input and is
+// popped, the panic being reported as a warning.
+func (s *OverlayStack) update(i int, msg tea.Msg) (cmd tea.Cmd) {
+	o := s.overlays[i]
+	defer func() {
+		if v := recover(); v != nil {
+			e := newPanicError(o.Model.GetModelID(), msg, v)
+			logPanic(modelLogger(o.Model), e)
+			s.overlays[i].Modal = false
+			cmd = tea.Batch(ModelErrCmd(e.ModelID, WarningSeverity, e), PopOverlayCmd(e.ModelID))
+		}
+	}()
+
+	o.Model, cmd = updateModel(o.Model, msg)
+	s.overlays[i] = o
+	return cmd
+}
+
+// broadcast passes the message to all of the overlay models it is routed to.
+// The screen size is translated to the size of each overlay.
+func (s *OverlayStack) broadcast(msg tea.Msg) tea.Cmd {
+	var cmds []tea.Cmd
+	for i := range s.Len() {
+		routed, ok := routeTo(s.overlays[i].Model, msg)
+		if !ok {
+			continue
+		}
+		if size, ok := msg.(tea.WindowSizeMsg); ok {
+			r := s.overlays[i].rect(size.Width, size.Height)
+			routed = tea.WindowSizeMsg{Width: r.Width, Height: r.Height}
+		}
+		cmds = append(cmds, s.update(i, routed))
+	}
+	return tea.Batch(cmds...)
+}
+
+// View draws the overlays over the base view of a w by h screen.
+func (s *OverlayStack) View(base string, w, h int) string {
+	view := fitRect(base, w, h)
+	for _, o := range s.overlays {
+		if o.Dim {
+			view = dim(view)
+		}
+		r := o.rect(w, h)
+		view = placeOver(view, fitRect(safeView(o.Model, r.Width, r.Height), r.Width, r.Height), r.X, r.Y)
+	}
+	return view
+}
+
+// dim renders the view with faint colorless text.
+func dim(view string) string {
+	style := lipgloss.NewStyle().Faint(true)
+	lines := strings.Split(ansi.Strip(view), "\n")
+	for i, line := range lines {
+		lines[i] = style.Render(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// placeOver draws the over block on top of the base view, with its top left
+// corner at the x, y cell.
+func placeOver(base, over string, x, y int) string {
+	lines := strings.Split(base, "\n")
+	for i, line := range strings.Split(over, "\n") {
+		row := y + i
+		if row < 0 || row >= len(lines) {
+			continue
+		}
+		left := ansi.Truncate(lines[row], x, "")
+		if lw := ansi.StringWidth(left); lw < x {
+			left += strings.Repeat(" ", x-lw)
+		}
+		right := ansi.TruncateLeft(lines[row], x+ansi.StringWidth(line), "")
+		lines[row] = left + "\x1b[m" + line + "\x1b[m" + right
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Msg/Cmd's
+
+type (
+	// PushOverlayMsg is sent to the root model to draw a new overlay on top
+	// of the others.
+	PushOverlayMsg struct{ Overlay Overlay }
+
+	// PopOverlayMsg is sent to the root model to remove the overlay of the
+	// model identified by ModelID, or the topmost one when empty.
+	PopOverlayMsg struct{ ModelID string }
+)
+
+// PushOverlayCmd returns a message pushing a new overlay.
+func PushOverlayCmd(o Overlay) tea.Cmd {
+	return func() tea.Msg {
+		return PushOverlayMsg{Overlay: o}
+	}
+}
+
+// PopOverlayCmd returns a message removing the overlay of the model instance
+// id, or the topmost overlay when id is empty.
+func PopOverlayCmd(id string) tea.Cmd {
+	return func() tea.Msg {
+		return PopOverlayMsg{ModelID: id}
+	}
+}
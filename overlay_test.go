@@ -0,0 +1,85 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// panickingLeaf is a leaf model recording the messages it receives and
+// panicking in Update.
+type panickingLeaf struct {
+	testLeaf
+}
+
+func (m panickingLeaf) Update(msg tea.Msg) (LeafModel, tea.Cmd) {
+	m.log.add(m.ID, msg)
+	panic("boom")
+}
+
+func TestOverlayWindowSize(t *testing.T) {
+	log := &msgLog{}
+	s := NewOverlayStack()
+	s.push(Overlay{Model: newTestLeaf(log, "dialog-1")}, nil, nil, 80, 24)
+	s.push(Overlay{Model: newTestLeaf(log, "help-1"), Placement: FullScreenPlacement}, nil, nil, 80, 24)
+
+	s.broadcast(tea.WindowSizeMsg{Width: 100, Height: 40})
+
+	tests := []struct {
+		id   string
+		want tea.WindowSizeMsg
+	}{
+		{"dialog-1", tea.WindowSizeMsg{Width: 50, Height: 20}},
+		{"help-1", tea.WindowSizeMsg{Width: 100, Height: 40}},
+	}
+	for _, tt := range tests {
+		if msgs := log.msgs[tt.id]; len(msgs) != 1 || msgs[0] != tea.Msg(tt.want) {
+			t.Errorf("%s messages = %v, want %v", tt.id, msgs, tt.want)
+		}
+	}
+}
+
+func TestOverlayPanic(t *testing.T) {
+	s := NewOverlayStack()
+	s.push(Overlay{Model: panickingLeaf{newTestLeaf(&msgLog{}, "dialog-1")}, Modal: true}, nil, nil, 80, 24)
+
+	if cmd := s.update(s.modal(), tea.KeyMsg{Type: tea.KeyCtrlC}); cmd == nil {
+		t.Error("panic not reported")
+	}
+	if i := s.modal(); i >= 0 {
+		t.Errorf("panicking overlay %d still modal", i)
+	}
+}
+
+func TestOverlayContext(t *testing.T) {
+	parent, cancel := context.WithCancelCause(context.Background())
+	popped, linked := newTestLeaf(&msgLog{}, "dialog-1"), newTestLeaf(&msgLog{}, "dialog-2")
+	s := NewOverlayStack()
+	s.push(Overlay{Model: popped}, parent, nil, 80, 24)
+	s.push(Overlay{Model: linked}, parent, nil, 80, 24)
+
+	if !s.pop("dialog-1") {
+		t.Fatal("dialog-1 not popped")
+	}
+	if err := popped.Ctx.Err(); err == nil {
+		t.Error("popped overlay context not cancelled")
+	}
+
+	cause := errors.New("closed")
+	cancel(cause)
+	select {
+	case <-linked.Ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("overlay context not cancelled along with its parent")
+	}
+	if got := context.Cause(linked.Ctx); got != cause {
+		t.Errorf("overlay cause = %v, want %v", got, cause)
+	}
+}
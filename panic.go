@@ -0,0 +1,113 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// PanicError is the error reported when a model panics in its Update() or
+// View() method. The panic is recovered so that the program keeps running,
+// or at least quits cleanly, restoring the terminal.
+type PanicError struct {
+	ModelID string
+
+	// The type of the message being processed, empty for View().
+	MsgType string
+
+	// The value passed to panic and the goroutine stack trace.
+	Value any
+	Stack []byte
+}
+
+func (e PanicError) Error() string {
+	if e.MsgType == "" {
+		return fmt.Sprintf("model %s panicked in View: %v", e.ModelID, e.Value)
+	}
+	return fmt.Sprintf("model %s panicked on %s: %v", e.ModelID, e.MsgType, e.Value)
+}
+
+// newPanicError returns the PanicError of a recovered panic value. A nil msg
+// means the panic occured in View().
+func newPanicError(id string, msg tea.Msg, v any) PanicError {
+	e := PanicError{ModelID: id, Value: v, Stack: debug.Stack()}
+	if msg != nil {
+		e.MsgType = fmt.Sprintf("%T", msg)
+	}
+	return e
+}
+
+// logPanic logs a recovered panic with its stack trace, with the logger
+// scoped with the panicking model ID.
+func logPanic(logger *slog.Logger, e PanicError) {
+	logger.Error("Model panic recovered",
+		"OnMsg", e.MsgType,
+		"panic", e.Value,
+		"stack", string(e.Stack))
+}
+
+// childPanicked reports a child model panic recovered in UpdateNodeModel.
+// The panic is a recoverable error of the child, or a warning when the child
+// gets disabled, see DisableOnPanic.
+func (m DefaultBranchModel) childPanicked(model CommonModel, msg tea.Msg, v any) tea.Cmd {
+	e := newPanicError(model.GetModelID(), msg, v)
+	logPanic(modelLogger(model), e)
+
+	if !m.DisableOnPanic {
+		return ModelErrCmd(e.ModelID, RecoverableSeverity, e)
+	}
+	// Disable the model in the registry, its Update() may panic on any
+	// message.
+	if m.Models.disablePanicked(e.ModelID) {
+		m.LogAction(msg, "Disabled panicked model")
+		return ModelErrCmd(e.ModelID, WarningSeverity, e)
+	}
+	// Don't loop on models panicking on any message.
+	if add, ok := msg.(AddPropertyMsg); ok && add.Property.Has(Disabled) {
+		return ModelErrCmd(e.ModelID, WarningSeverity, e)
+	}
+	m.LogAction(msg, "Requesting panicked model disabling")
+	return tea.Batch(
+		ModelErrCmd(e.ModelID, WarningSeverity, e),
+		AddPropertyCmd([]string{e.ModelID}, Disabled),
+	)
+}
+
+// reachesPanicked returns whether msg is still passed down to the children
+// disabled after panicking: the property messages that may enable them
+// again, and the messages of the shutdown sequence.
+func reachesPanicked(msg tea.Msg) bool {
+	switch msg.(type) {
+	case SetDisabledMsg, SetPropertyMsg, AddPropertyMsg, UnsetPropertyMsg,
+		ShutDownMsg, ModelFinishedMsg, shutdownDeadlineMsg:
+		return true
+	}
+	return false
+}
+
+// safeView renders the model in a w by h window, recovering from panics: the
+// panic is logged and its error is rendered instead, using the model logger
+// and theme when available. The view is marked with the model region, for
+// mouse hit-testing.
+func safeView(model CommonModel, w, h int) (view string) {
+	defer func() {
+		if v := recover(); v != nil {
+			e := newPanicError(model.GetModelID(), nil, v)
+
+			theme := DefaultMinimalTheme()
+			if t, ok := model.(interface{ GetTheme() Themer }); ok {
+				theme = t.GetTheme()
+			}
+			logPanic(modelLogger(model), e)
+			view = theme.GetBaseStyle().Width(w).MaxWidth(w).MaxHeight(h).Render(theme.RenderErrorText(e.Error()))
+			view = markRegion(model.GetModelID(), view, w, h)
+		}
+	}()
+	return markRegion(model.GetModelID(), model.View(w, h), w, h)
+}
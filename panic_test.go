@@ -0,0 +1,56 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDisableOnPanic(t *testing.T) {
+	log := &msgLog{}
+	tabs := newTestBranch(log, "tabs-1", panickingLeaf{newTestLeaf(log, "pane-1")}, newTestLeaf(log, "pane-2"))
+	tabs.DisableOnPanic = true
+
+	cmd := tabs.UpdateNodeModels(tea.WindowSizeMsg{Width: 80, Height: 24})
+
+	pane, _ := tabs.Models.Get("pane-1")
+	if !pane.IsDisabled() {
+		t.Error("panicking model not disabled")
+	}
+	if _, ok := pane.(panickingLeaf); !ok {
+		t.Errorf("disabled model type = %T, want panickingLeaf", pane)
+	}
+	if got := tabs.Models.held["pane-1"]; got != Disabled {
+		t.Errorf("indexed properties = %v, want %v", got, Disabled)
+	}
+	if other, _ := tabs.Models.Get("pane-2"); other.IsDisabled() {
+		t.Error("other model disabled")
+	}
+
+	e, _ := cmd().(ErrMsg)
+	var panicErr PanicError
+	if e.Severity != WarningSeverity || !errors.As(e.Err, &panicErr) || panicErr.ModelID != "pane-1" {
+		t.Errorf("reported error = %+v, want a pane-1 panic warning", e)
+	}
+
+	// The disabled model is skipped, but for property and shutdown messages.
+	cmd = tabs.UpdateNodeModels(tea.WindowSizeMsg{Width: 100, Height: 40})
+	if got, want := log.got("pane-1"), []string{"tea.WindowSizeMsg"}; !slices.Equal(got, want) {
+		t.Errorf("messages = %v, want %v", got, want)
+	}
+	if cmd != nil {
+		if e, ok := cmd().(ErrMsg); ok {
+			t.Errorf("reported error = %+v, want none", e)
+		}
+	}
+	tabs.UpdateNodeModels(UnsetPropertyMsg{ModelIDs: []string{"pane-1"}, Property: Disabled})
+	if got, want := log.got("pane-1"), []string{"tea.WindowSizeMsg", "bubbletree.UnsetPropertyMsg"}; !slices.Equal(got, want) {
+		t.Errorf("messages = %v, want %v", got, want)
+	}
+}
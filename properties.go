@@ -0,0 +1,330 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"fmt"
+	"math/bits"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// The possible bubble tree model properties
+const (
+	// Disabled models don't respond to loop events.
+	Disabled Properties = 1 << iota
+
+	// Focused models receive the console input.
+	Focused
+
+	// Hidden models are excluded from rendering, layouts give their space
+	// to the other items.
+	Hidden
+
+	// Modal models capture all of the console input, wherever the focus is.
+	// When several models are modal, the last one made modal captures it.
+	Modal
+
+	// ReadOnly models display their data but don't accept edits of it. The
+	// models holding it discard the input that would change their data, see
+	// IsReadOnly.
+	ReadOnly
+)
+
+// exclusiveProperties are held by the listed models of a SetPropertyMsg only,
+// the other models release them. Only one model subtree may be enabled, like
+// with SetDisabledMsg, and only one model may capture the console input. The
+// other properties, e.g., Hidden or ReadOnly, describe each model on its own
+// and any number of models may hold them.
+const exclusiveProperties = Disabled | Modal
+
+type Properties int
+
+var (
+	// Guards the property names.
+	propertiesMu sync.RWMutex
+
+	// The names of the framework and registered application properties, by
+	// bit position.
+	propertyNames = []string{"DISABLED", "FOCUSED", "HIDDEN", "MODAL", "READONLY"}
+)
+
+// RegisterProperty registers a named application property and returns its
+// bit. It is meant to be called at package initialization, e.g.:
+//
+//	var Selected = bubbletree.RegisterProperty("SELECTED")
+//
+// It panics when the name is already registered or when no bits are left.
+func RegisterProperty(name string) Properties {
+	propertiesMu.Lock()
+	defer propertiesMu.Unlock()
+
+	name = strings.ToUpper(name)
+	if slices.Contains(propertyNames, name) {
+		panic(fmt.Sprintf("bubbletree: property %q already registered", name))
+	}
+	if len(propertyNames) >= bits.UintSize-1 {
+		panic(fmt.Sprintf("bubbletree: no property bit left to register %q", name))
+	}
+	prop := Properties(1) << len(propertyNames)
+	propertyNames = append(propertyNames, name)
+	return prop
+}
+
+func (p Properties) String() string {
+	if p == 0 {
+		return "NONE"
+	}
+
+	propertiesMu.RLock()
+	defer propertiesMu.RUnlock()
+
+	var props []string
+	for i, name := range propertyNames {
+		if p&(1<<i) != 0 {
+			props = append(props, name)
+		}
+	}
+	if unknown := p &^ (1<<len(propertyNames) - 1); unknown != 0 {
+		props = append(props, fmt.Sprintf("0x%x", int(unknown)))
+	}
+	return strings.Join(props, "|")
+}
+
+// MarshalText implements encoding.TextMarshaler, so that properties are
+// readable in JSON tree dumps.
+func (p Properties) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, decoding the properties
+// encoded by MarshalText.
+func (p *Properties) UnmarshalText(text []byte) error {
+	*p = 0
+	if string(text) == "NONE" {
+		return nil
+	}
+
+	propertiesMu.RLock()
+	defer propertiesMu.RUnlock()
+
+	for name := range strings.SplitSeq(string(text), "|") {
+		if i := slices.Index(propertyNames, name); i >= 0 {
+			*p |= 1 << i
+			continue
+		}
+		unknown, err := strconv.ParseInt(name, 0, 0)
+		if err != nil {
+			return fmt.Errorf("unknown property %q", name)
+		}
+		*p |= Properties(unknown)
+	}
+	return nil
+}
+
+// Has returns whether all of the prop properties are set.
+func (p Properties) Has(prop Properties) bool {
+	return p&prop == prop
+}
+
+// Set sets the prop properties.
+func (p *Properties) Set(prop Properties) (old, new Properties) {
+	old = *p
+	*p |= prop
+	return old, *p
+}
+
+// Unset unsets the prop properties.
+func (p *Properties) Unset(prop Properties) (old, new Properties) {
+	old = *p
+	*p &= ^prop
+	return old, *p
+}
+
+// SetDisabled sets the Disabled model property.
+func (p *Properties) SetDisabled() (old, new Properties) {
+	return p.Set(Disabled)
+}
+
+// UnsetDisabled unsets the Disabled model property.
+func (p *Properties) UnsetDisabled() (old, new Properties) {
+	return p.Unset(Disabled)
+}
+
+// SetFocused sets the Focused model property.
+func (p *Properties) SetFocused() (old, new Properties) {
+	return p.Set(Focused)
+}
+
+// UnsetFocused unsets the Focused model property.
+func (p *Properties) UnsetFocused() (old, new Properties) {
+	return p.Unset(Focused)
+}
+
+// IsHidden returns whether the model's instance has the "Hidden" property
+// set.
+func (m DefaultCommonModel) IsHidden() bool {
+	return m.Properties.Has(Hidden)
+}
+
+// IsModal returns whether the model's instance has the "Modal" property set.
+func (m DefaultCommonModel) IsModal() bool {
+	return m.Properties.Has(Modal)
+}
+
+// IsReadOnly returns whether the model's instance has the "ReadOnly"
+// property set.
+func (m DefaultCommonModel) IsReadOnly() bool {
+	return m.Properties.Has(ReadOnly)
+}
+
+// updateProperty sets or unsets the prop properties, logging the change.
+func (m *DefaultCommonModel) updateProperty(msg any, prop Properties, set bool) {
+	var old, new Properties
+	if set {
+		old, new = m.Properties.Set(prop)
+	} else {
+		old, new = m.Properties.Unset(prop)
+	}
+	if new != old {
+		m.LogPropertyChange(msg, old, new)
+	}
+}
+
+// withProperties returns the model with the properties of its embedded
+// DefaultCommonModel replaced, without going through its Update(). It returns
+// false, and the model unchanged, when it doesn't embed one.
+func withProperties(model CommonModel, props Properties) (CommonModel, bool) {
+	v := reflect.ValueOf(model)
+	isPointer := v.Kind() == reflect.Pointer
+	if isPointer {
+		if v.IsNil() {
+			return model, false
+		}
+		v = v.Elem()
+	} else {
+		copied := reflect.New(v.Type()).Elem()
+		copied.Set(v)
+		v = copied
+	}
+	if v.Kind() != reflect.Struct {
+		return model, false
+	}
+	f, ok := v.Type().FieldByName("Properties")
+	if !ok || f.Type != reflect.TypeFor[Properties]() {
+		return model, false
+	}
+	field, err := v.FieldByIndexErr(f.Index)
+	if err != nil || !field.CanSet() {
+		return model, false
+	}
+	field.Set(reflect.ValueOf(props))
+	if isPointer {
+		return model, true
+	}
+	return v.Interface().(CommonModel), true
+}
+
+// setProperty sets the property of a SetPropertyMsg on the recipients, and
+// releases its exclusive properties on the other models.
+func (m *DefaultCommonModel) setProperty(msg SetPropertyMsg) {
+	if msg.IsRecipient(m.GetModelID()) {
+		m.updateProperty(msg, msg.Property, true)
+	} else if released := msg.Property & exclusiveProperties; released != 0 {
+		m.updateProperty(msg, released, false)
+	}
+}
+
+// Msg/Cmd's
+
+type (
+	// SetPropertyMsg is an addressed message sent to request that the listed
+	// model instances have the property set. Like with SetDisabledMsg, the
+	// other models holding Disabled or Modal unset them, those properties
+	// being exclusive. The other properties aren't, they are set like with
+	// AddPropertyMsg.
+	SetPropertyMsg struct {
+		ModelIDs []string
+		Property Properties
+	}
+
+	// AddPropertyMsg is an addressed message sent to request that the listed
+	// model instances have the property set, leaving the other models as is.
+	AddPropertyMsg struct {
+		ModelIDs []string
+		Property Properties
+	}
+
+	// UnsetPropertyMsg is an addressed message sent to request that the
+	// listed model instances have the property unset.
+	UnsetPropertyMsg struct {
+		ModelIDs []string
+		Property Properties
+	}
+)
+
+// IsRecipient returns whether the message is destined to the specified model
+// instance.
+func (msg SetPropertyMsg) IsRecipient(id string) bool {
+	return slices.Contains(msg.ModelIDs, id)
+}
+
+// MsgRoute implements the Addressed interface. The message is forwarded down
+// the subtrees holding the listed model instances, and the ones currently
+// holding the exclusive properties so that they can release them.
+func (msg SetPropertyMsg) MsgRoute() Route {
+	return Route{ModelIDs: msg.ModelIDs, Release: msg.Property & exclusiveProperties}
+}
+
+// IsRecipient returns whether the message is destined to the specified model
+// instance.
+func (msg AddPropertyMsg) IsRecipient(id string) bool {
+	return slices.Contains(msg.ModelIDs, id)
+}
+
+// MsgRoute implements the Addressed interface.
+func (msg AddPropertyMsg) MsgRoute() Route {
+	return Route{ModelIDs: msg.ModelIDs}
+}
+
+// IsRecipient returns whether the message is destined to the specified model
+// instance.
+func (msg UnsetPropertyMsg) IsRecipient(id string) bool {
+	return slices.Contains(msg.ModelIDs, id)
+}
+
+// MsgRoute implements the Addressed interface.
+func (msg UnsetPropertyMsg) MsgRoute() Route {
+	return Route{ModelIDs: msg.ModelIDs}
+}
+
+// SetPropertyCmd returns a message to set the property on the targeted
+// model instances, and unset it on the others when Disabled or Modal.
+func SetPropertyCmd(ids []string, prop Properties) tea.Cmd {
+	return func() tea.Msg {
+		return SetPropertyMsg{ModelIDs: ids, Property: prop}
+	}
+}
+
+// AddPropertyCmd returns a message to set the property on the targeted model
+// instances only.
+func AddPropertyCmd(ids []string, prop Properties) tea.Cmd {
+	return func() tea.Msg {
+		return AddPropertyMsg{ModelIDs: ids, Property: prop}
+	}
+}
+
+// UnsetPropertyCmd returns a message to unset the property on the targeted
+// model instances.
+func UnsetPropertyCmd(ids []string, prop Properties) tea.Cmd {
+	return func() tea.Msg {
+		return UnsetPropertyMsg{ModelIDs: ids, Property: prop}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSetProperty(t *testing.T) {
+	tests := []struct {
+		name string
+		msgs []tea.Msg
+		want map[string]Properties
+	}{
+		{
+			name: "exclusive",
+			msgs: []tea.Msg{
+				SetPropertyMsg{ModelIDs: []string{"pane-1"}, Property: Disabled},
+				SetPropertyMsg{ModelIDs: []string{"status-1"}, Property: Disabled},
+			},
+			want: map[string]Properties{"pane-1": 0, "status-1": Disabled},
+		},
+		{
+			name: "not exclusive",
+			msgs: []tea.Msg{
+				SetPropertyMsg{ModelIDs: []string{"pane-1"}, Property: Hidden},
+				SetPropertyMsg{ModelIDs: []string{"status-1"}, Property: Hidden},
+			},
+			want: map[string]Properties{"pane-1": Hidden, "status-1": Hidden},
+		},
+		{
+			name: "read only",
+			msgs: []tea.Msg{
+				SetPropertyMsg{ModelIDs: []string{"pane-1"}, Property: ReadOnly},
+				SetPropertyMsg{ModelIDs: []string{"status-1"}, Property: ReadOnly},
+			},
+			want: map[string]Properties{"pane-1": ReadOnly, "status-1": ReadOnly},
+		},
+		{
+			name: "mixed",
+			msgs: []tea.Msg{
+				SetPropertyMsg{ModelIDs: []string{"pane-1"}, Property: Modal | Hidden},
+				SetPropertyMsg{ModelIDs: []string{"status-1"}, Property: Modal | Hidden},
+			},
+			want: map[string]Properties{"pane-1": Hidden, "status-1": Modal | Hidden},
+		},
+		{
+			name: "added and unset",
+			msgs: []tea.Msg{
+				AddPropertyMsg{ModelIDs: []string{"pane-1", "status-1"}, Property: Disabled},
+				UnsetPropertyMsg{ModelIDs: []string{"status-1"}, Property: Disabled},
+			},
+			want: map[string]Properties{"pane-1": Disabled, "status-1": 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := testTree(&msgLog{})
+			var root tea.Model = New(app)
+			for _, msg := range tt.msgs {
+				root, _ = root.Update(msg)
+			}
+			for id, want := range tt.want {
+				model, _, _ := Find(app, id)
+				if got := model.GetProperties(); got != want {
+					t.Errorf("%s properties = %v, want %v", id, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestModalID(t *testing.T) {
+	app := testTree(&msgLog{})
+	var root tea.Model = New(app)
+	modalID := func() string { return root.(DefaultRootModel).modalID() }
+
+	steps := []struct {
+		msg  tea.Msg
+		want string
+	}{
+		{AddPropertyMsg{ModelIDs: []string{"pane-1"}, Property: Modal}, "pane-1"},
+		{AddPropertyMsg{ModelIDs: []string{"status-1"}, Property: Modal}, "status-1"},
+		{AddPropertyMsg{ModelIDs: []string{"status-1"}, Property: Hidden}, "pane-1"},
+		{UnsetPropertyMsg{ModelIDs: []string{"pane-1"}, Property: Modal}, ""},
+		{UnsetPropertyMsg{ModelIDs: []string{"status-1"}, Property: Hidden}, "status-1"},
+	}
+	for _, step := range steps {
+		root, _ = root.Update(step.msg)
+		if got := modalID(); got != step.want {
+			t.Errorf("after %+v: modal = %q, want %q", step.msg, got, step.want)
+		}
+	}
+}
@@ -0,0 +1,287 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// RecordedMsg is a message received by the root model, as written by a
+// Recorder, one JSON object per line.
+type RecordedMsg struct {
+	// When the message was received.
+	Time time.Time `json:"time"`
+
+	// The registered message type name, or its Go type for unregistered
+	// types, see RegisterMsg.
+	Type string `json:"type"`
+
+	// The encoded message, empty for unregistered types.
+	Msg json.RawMessage `json:"msg,omitempty"`
+}
+
+// MsgCodec encodes and decodes the recorded messages of a type.
+type MsgCodec struct {
+	Encode func(msg tea.Msg) (json.RawMessage, error)
+	Decode func(data json.RawMessage) (tea.Msg, error)
+}
+
+// msgRegistry holds the recordable message types.
+var msgRegistry = struct {
+	sync.RWMutex
+	names  map[reflect.Type]string
+	codecs map[string]MsgCodec
+}{
+	names:  map[reflect.Type]string{},
+	codecs: map[string]MsgCodec{},
+}
+
+// RegisterMsg registers the message type T under name, e.g.,
+// "configurator.ConfigReadyMsg", so that it is recorded and replayed. The
+// messages are encoded in JSON: unexported fields and fields tagged with
+// `json:"-"` aren't recorded. Use RegisterMsgCodec for types JSON can't
+// encode. It panics if the name or the type is already registered.
+func RegisterMsg[T tea.Msg](name string) {
+	RegisterMsgCodec[T](name, MsgCodec{
+		Encode: func(msg tea.Msg) (json.RawMessage, error) {
+			return json.Marshal(msg)
+		},
+		Decode: func(data json.RawMessage) (tea.Msg, error) {
+			var msg T
+			err := json.Unmarshal(data, &msg)
+			return msg, err
+		},
+	})
+}
+
+// RegisterMsgCodec registers the message type T under name, recorded and
+// replayed with codec. It panics if the name or the type is already
+// registered.
+func RegisterMsgCodec[T tea.Msg](name string, codec MsgCodec) {
+	msgRegistry.Lock()
+	defer msgRegistry.Unlock()
+
+	t := reflect.TypeFor[T]()
+	if _, ok := msgRegistry.codecs[name]; ok {
+		panic(fmt.Sprintf("bubbletree: recorded message name %q registered twice", name))
+	}
+	if _, ok := msgRegistry.names[t]; ok {
+		panic(fmt.Sprintf("bubbletree: recorded message type %v registered twice", t))
+	}
+	msgRegistry.names[t] = name
+	msgRegistry.codecs[name] = codec
+}
+
+// IsRecordable returns whether the message type is registered.
+func IsRecordable(msg tea.Msg) bool {
+	msgRegistry.RLock()
+	defer msgRegistry.RUnlock()
+	_, ok := msgRegistry.names[reflect.TypeOf(msg)]
+	return ok
+}
+
+// encodeMsg returns the registered type name and the encoding of msg.
+func encodeMsg(msg tea.Msg) (string, json.RawMessage, error) {
+	msgRegistry.RLock()
+	name, ok := msgRegistry.names[reflect.TypeOf(msg)]
+	codec := msgRegistry.codecs[name]
+	msgRegistry.RUnlock()
+
+	if !ok {
+		return fmt.Sprintf("%T", msg), nil, fmt.Errorf("message type %T is not registered", msg)
+	}
+	data, err := codec.Encode(msg)
+	return name, data, err
+}
+
+// decodeMsg returns the message of the registered type name.
+func decodeMsg(name string, data json.RawMessage) (tea.Msg, error) {
+	msgRegistry.RLock()
+	codec, ok := msgRegistry.codecs[name]
+	msgRegistry.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("message type %q is not registered", name)
+	}
+	return codec.Decode(data)
+}
+
+// Recorder writes the messages received by the root model, one RecordedMsg
+// per line, to reproduce a program session with Replay. Messages of
+// unregistered types are recorded without their content, and skipped when
+// replayed. Pass it to the root model with WithRecorder.
+type Recorder struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	err error
+}
+
+// NewRecorder returns a recorder writing to w.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{enc: json.NewEncoder(w)}
+}
+
+// Err returns the first error that stopped the recording, if any.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// record writes the message, unless a previous write failed.
+func (r *Recorder) record(msg tea.Msg) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return
+	}
+	rec := RecordedMsg{Time: time.Now()}
+	var err error
+	if rec.Type, rec.Msg, err = encodeMsg(msg); err != nil {
+		rec.Msg = nil
+	}
+	if err := r.enc.Encode(rec); err != nil {
+		r.err = fmt.Errorf("while recording message %s: %w", rec.Type, err)
+	}
+}
+
+// ReadRecording reads the messages written by a Recorder.
+func ReadRecording(r io.Reader) ([]RecordedMsg, error) {
+	var recs []RecordedMsg
+	dec := json.NewDecoder(r)
+	for {
+		var rec RecordedMsg
+		if err := dec.Decode(&rec); errors.Is(err, io.EOF) {
+			return recs, nil
+		} else if err != nil {
+			return recs, fmt.Errorf("while reading recorded message %d: %w", len(recs)+1, err)
+		}
+		recs = append(recs, rec)
+	}
+}
+
+// Register the console input and the framework messages. Errors are
+// recorded as their text. TaskProgressMsg isn't registered: it carries the
+// wait for the next task message, so the replayed tasks report their own
+// progress and results.
+func init() {
+	RegisterMsg[tea.KeyMsg]("tea.KeyMsg")
+	RegisterMsg[tea.MouseMsg]("tea.MouseMsg")
+	RegisterMsg[tea.WindowSizeMsg]("tea.WindowSizeMsg")
+	RegisterMsg[tea.FocusMsg]("tea.FocusMsg")
+	RegisterMsg[tea.BlurMsg]("tea.BlurMsg")
+
+	RegisterMsg[ShutDownMsg]("bubbletree.ShutDownMsg")
+	RegisterMsg[ModelFinishedMsg]("bubbletree.ModelFinishedMsg")
+	RegisterMsg[SetFocusMsg]("bubbletree.SetFocusMsg")
+	RegisterMsg[SetDisabledMsg]("bubbletree.SetDisabledMsg")
+	RegisterMsg[RetryMsg]("bubbletree.RetryMsg")
+	RegisterMsg[FocusNextMsg]("bubbletree.FocusNextMsg")
+	RegisterMsg[FocusPrevMsg]("bubbletree.FocusPrevMsg")
+	RegisterMsg[PushFocusMsg]("bubbletree.PushFocusMsg")
+	RegisterMsg[PopFocusMsg]("bubbletree.PopFocusMsg")
+	RegisterMsg[MountedMsg]("bubbletree.MountedMsg")
+	RegisterMsg[UnmountMsg]("bubbletree.UnmountMsg")
+	RegisterMsg[UnmountedMsg]("bubbletree.UnmountedMsg")
+	RegisterMsg[MouseEnterMsg]("bubbletree.MouseEnterMsg")
+	RegisterMsg[MouseLeaveMsg]("bubbletree.MouseLeaveMsg")
+	RegisterMsg[NotifyMsg]("bubbletree.NotifyMsg")
+	RegisterMsg[PopOverlayMsg]("bubbletree.PopOverlayMsg")
+	RegisterMsg[SetPropertyMsg]("bubbletree.SetPropertyMsg")
+	RegisterMsg[AddPropertyMsg]("bubbletree.AddPropertyMsg")
+	RegisterMsg[UnsetPropertyMsg]("bubbletree.UnsetPropertyMsg")
+	RegisterMsg[StateChangedMsg]("bubbletree.StateChangedMsg")
+	RegisterMsg[SetLogLevelMsg]("bubbletree.SetLogLevelMsg")
+
+	RegisterMsgCodec[ErrMsg]("bubbletree.ErrMsg", MsgCodec{
+		Encode: func(msg tea.Msg) (json.RawMessage, error) {
+			e := msg.(ErrMsg)
+			return json.Marshal(recordedErrMsg{ErrMsg: e, Err: errText(e.Err)})
+		},
+		Decode: func(data json.RawMessage) (tea.Msg, error) {
+			var rec recordedErrMsg
+			err := json.Unmarshal(data, &rec)
+			rec.ErrMsg.Err = textErr(rec.Err)
+			return rec.ErrMsg, err
+		},
+	})
+	RegisterMsgCodec[TaskErrorMsg]("bubbletree.TaskErrorMsg", MsgCodec{
+		Encode: func(msg tea.Msg) (json.RawMessage, error) {
+			e := msg.(TaskErrorMsg)
+			return json.Marshal(recordedTaskErrorMsg{TaskErrorMsg: e, Err: errText(e.Err)})
+		},
+		Decode: func(data json.RawMessage) (tea.Msg, error) {
+			var rec recordedTaskErrorMsg
+			err := json.Unmarshal(data, &rec)
+			rec.TaskErrorMsg.Err = textErr(rec.Err)
+			return rec.TaskErrorMsg, err
+		},
+	})
+	RegisterMsgCodec[Envelope]("bubbletree.Envelope", MsgCodec{
+		Encode: func(msg tea.Msg) (json.RawMessage, error) {
+			e := msg.(Envelope)
+			name, data, err := encodeMsg(e.Msg)
+			if err != nil {
+				return nil, err
+			}
+			return json.Marshal(recordedEnvelope{Route: e.Route, Type: name, Msg: data})
+		},
+		Decode: func(data json.RawMessage) (tea.Msg, error) {
+			var rec recordedEnvelope
+			if err := json.Unmarshal(data, &rec); err != nil {
+				return nil, err
+			}
+			msg, err := decodeMsg(rec.Type, rec.Msg)
+			return Envelope{Route: rec.Route, Msg: msg}, err
+		},
+	})
+}
+
+// The recorded forms of the messages JSON can't encode: errors are recorded
+// as their text, the Err fields shadowing the embedded message ones, and
+// envelopes along with their message type.
+type (
+	recordedErrMsg struct {
+		ErrMsg
+		Err string
+	}
+	recordedTaskErrorMsg struct {
+		TaskErrorMsg
+		Err string
+	}
+	recordedEnvelope struct {
+		Route
+		Type string
+		Msg  json.RawMessage
+	}
+)
+
+// errText returns the error text, empty for nil errors.
+func errText(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// textErr returns an error with the text, nil for empty texts.
+func textErr(text string) error {
+	if text == "" {
+		return nil
+	}
+	return errors.New(text)
+}
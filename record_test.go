@@ -0,0 +1,113 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestMsgCodecs(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  tea.Msg
+		want tea.Msg
+	}{
+		{"key", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x"), Alt: true}, nil},
+		{"window size", tea.WindowSizeMsg{Width: 80, Height: 24}, nil},
+		{"framework", SetFocusMsg{ModelID: "pane-1"}, nil},
+		{"properties", AddPropertyMsg{ModelIDs: []string{"pane-1"}, Property: Modal | Hidden}, nil},
+		{
+			name: "error",
+			msg:  ErrMsg{Err: fmt.Errorf("wrapped: %w", errors.New("boom")), Severity: RecoverableSeverity, SourceModelID: "pane-1"},
+			want: ErrMsg{Err: errors.New("wrapped: boom"), Severity: RecoverableSeverity, SourceModelID: "pane-1"},
+		},
+		{"nil error", TaskErrorMsg{ModelID: "pane-1", TaskID: 2, Name: "count"}, nil},
+		{
+			name: "envelope",
+			msg:  Envelope{Route: Route{Path: []string{"app-1", "tabs-1"}}, Msg: tea.WindowSizeMsg{Width: 10, Height: 2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := tt.want
+			if want == nil {
+				want = tt.msg
+			}
+			name, data, err := encodeMsg(tt.msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := decodeMsg(name, data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("decoded %s = %#v, want %#v", data, got, want)
+			}
+		})
+	}
+}
+
+func TestMsgCodecsUnregistered(t *testing.T) {
+	type unregisteredMsg struct{}
+
+	name, data, err := encodeMsg(unregisteredMsg{})
+	if err == nil || data != nil || name != "bubbletree.unregisteredMsg" {
+		t.Errorf("encoded unregistered message as %q %s, %v", name, data, err)
+	}
+	if _, err := decodeMsg(name, data); err == nil {
+		t.Error("decoded unregistered message")
+	}
+	if _, _, err := encodeMsg(Envelope{Msg: unregisteredMsg{}}); err == nil {
+		t.Error("encoded envelope of an unregistered message")
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	type unregisteredMsg struct{}
+	msgs := []tea.Msg{
+		tea.WindowSizeMsg{Width: 80, Height: 24},
+		unregisteredMsg{},
+		tea.KeyMsg{Type: tea.KeyEsc},
+	}
+
+	var buf bytes.Buffer
+	r := NewRecorder(&buf)
+	for _, msg := range msgs {
+		r.record(msg)
+	}
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	recs, err := ReadRecording(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recs) != len(msgs) {
+		t.Fatalf("read %d messages, want %d", len(recs), len(msgs))
+	}
+	for i, rec := range recs {
+		got, err := decodeMsg(rec.Type, rec.Msg)
+		if !IsRecordable(msgs[i]) {
+			if err == nil || rec.Msg != nil {
+				t.Errorf("message %d: unregistered %s recorded as %s", i, rec.Type, rec.Msg)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, msgs[i]) {
+			t.Errorf("message %d = %#v, %v, want %#v", i, got, err, msgs[i])
+		}
+		if i > 0 && rec.Time.Before(recs[i-1].Time) {
+			t.Errorf("message %d recorded before the previous one", i)
+		}
+	}
+}
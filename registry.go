@@ -0,0 +1,514 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+)
+
+// UpdateMode selects how a branch model runs the Update method of its
+// children.
+type UpdateMode int
+
+const (
+	// ConcurrentUpdate runs each child Update in its own goroutine. The
+	// returned commands are still gathered in registry order.
+	ConcurrentUpdate UpdateMode = iota
+
+	// SequentialUpdate runs each child Update one after the other, in registry
+	// order.
+	SequentialUpdate
+)
+
+func (u UpdateMode) String() string {
+	switch u {
+	case ConcurrentUpdate:
+		return "CONCURRENT"
+	case SequentialUpdate:
+		return "SEQUENTIAL"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// Registry is the ordered set of child models linked to a branch model.
+// Children are kept sorted by priority, lower first, then by insertion order,
+// so that iterating over them, and the commands they return, is
+// deterministic. A Registry is safe for concurrent use. A nil Registry is
+// empty and ignores changes.
+//
+// A registry also indexes all of the descendant models of its branch model,
+// its children and theirs, so that addressed messages are routed without
+// walking the tree. The registries of the branch model children report their
+// own changes to it.
+type Registry struct {
+	mu      sync.RWMutex
+	entries []registryEntry
+	index   map[string]int
+	mode    UpdateMode
+	lastSeq uint64
+
+	// The registry of the parent branch model, if linked.
+	parent *Registry
+
+	// The focus manager of the root model the tree is linked to, if any.
+	focus *FocusManager
+
+	// The IDs of all of the descendant models, and the properties of those
+	// holding any.
+	descendants map[string]struct{}
+	held        map[string]Properties
+
+	// The IDs of the descendant models capturing the console input, Modal
+	// and neither Disabled nor Hidden, in the order they became so.
+	modals []string
+}
+
+// registryEntry is a registered model and its ordering keys.
+type registryEntry struct {
+	model    CommonModel
+	priority int
+	seq      uint64
+
+	// Stops the cancellation of the model context along with the branch
+	// model one, see DefaultBranchModel.LinkNewModel.
+	stop func() bool
+
+	// Whether the model was disabled after panicking, see disablePanicked.
+	panicked bool
+}
+
+// NewRegistry returns a new empty registry using the ConcurrentUpdate mode.
+func NewRegistry() *Registry {
+	return &Registry{index: make(map[string]int)}
+}
+
+// Store adds the model to the registry with the default priority (0). If a
+// model with the same ID is already registered, it is replaced in place.
+func (r *Registry) Store(model CommonModel) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	i, ok := r.index[model.GetModelID()]
+	if ok {
+		r.entries[i].model = model
+		r.entries[i].panicked = r.entries[i].panicked && model.IsDisabled()
+	} else {
+		r.insert(model, 0)
+	}
+	r.mu.Unlock()
+
+	if ok {
+		r.track(map[string]Properties{model.GetModelID(): model.GetProperties()})
+	} else {
+		r.track(r.adopt(model))
+	}
+}
+
+// StoreWithPriority adds the model to the registry, or moves an already
+// registered model, at the position given by priority. Lower priorities come
+// first.
+func (r *Registry) StoreWithPriority(model CommonModel, priority int) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	var (
+		stop     func() bool
+		panicked bool
+	)
+	if i, ok := r.index[model.GetModelID()]; ok {
+		stop = r.entries[i].stop
+		panicked = r.entries[i].panicked && model.IsDisabled()
+		r.remove(i)
+	}
+	i := r.insert(model, priority)
+	r.entries[i].stop = stop
+	r.entries[i].panicked = panicked
+	r.mu.Unlock()
+
+	r.track(r.adopt(model))
+}
+
+// Get returns the model identified by id and whether it was found.
+func (r *Registry) Get(id string) (CommonModel, bool) {
+	if r == nil {
+		return nil, false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if i, ok := r.index[id]; ok {
+		return r.entries[i].model, true
+	}
+	return nil, false
+}
+
+// Delete removes the model identified by id, returning whether it was
+// registered. The model context is no longer cancelled along with the
+// branch model one.
+func (r *Registry) Delete(id string) bool {
+	if r == nil {
+		return false
+	}
+	r.mu.Lock()
+	i, ok := r.index[id]
+	var e registryEntry
+	if ok {
+		e = r.entries[i]
+		r.remove(i)
+	}
+	r.mu.Unlock()
+
+	if !ok {
+		return false
+	}
+	if e.stop != nil {
+		e.stop()
+	}
+	r.untrack(r.release(e.model))
+	return true
+}
+
+// Len returns the number of registered models.
+func (r *Registry) Len() int {
+	if r == nil {
+		return 0
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.entries)
+}
+
+// IDs returns the registered model IDs, in order.
+func (r *Registry) IDs() []string {
+	var ids []string
+	r.Range(func(model CommonModel) bool {
+		ids = append(ids, model.GetModelID())
+		return true
+	})
+	return ids
+}
+
+// Models returns a snapshot of the registered models, in order.
+func (r *Registry) Models() []CommonModel {
+	if r == nil {
+		return nil
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	models := make([]CommonModel, len(r.entries))
+	for i, e := range r.entries {
+		models[i] = e.model
+	}
+	return models
+}
+
+// Range calls fn sequentially for each registered model, in order. If fn
+// returns false, the iteration stops. Range iterates over a snapshot of the
+// registry, fn may modify the registry.
+func (r *Registry) Range(fn func(model CommonModel) bool) {
+	for _, model := range r.Models() {
+		if !fn(model) {
+			return
+		}
+	}
+}
+
+// SetUpdateMode sets how the owning branch model runs its children Update.
+func (r *Registry) SetUpdateMode(mode UpdateMode) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.mode = mode
+}
+
+// UpdateMode returns how the owning branch model runs its children Update.
+func (r *Registry) UpdateMode() UpdateMode {
+	if r == nil {
+		return ConcurrentUpdate
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.mode
+}
+
+// insert adds a new entry at its ordered position, returned. The caller holds
+// the lock.
+func (r *Registry) insert(model CommonModel, priority int) int {
+	r.lastSeq++
+	e := registryEntry{model: model, priority: priority, seq: r.lastSeq}
+	i, _ := slices.BinarySearchFunc(r.entries, e, func(a, b registryEntry) int {
+		if c := cmp.Compare(a.priority, b.priority); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.seq, b.seq)
+	})
+	r.entries = slices.Insert(r.entries, i, e)
+	r.reindex(i)
+	return i
+}
+
+// remove deletes the entry at position i. The caller holds the lock.
+func (r *Registry) remove(i int) {
+	delete(r.index, r.entries[i].model.GetModelID())
+	r.entries = slices.Delete(r.entries, i, i+1)
+	r.reindex(i)
+}
+
+// reindex refreshes the id to position index from position 'from' onward. The
+// caller holds the lock.
+func (r *Registry) reindex(from int) {
+	if r.index == nil {
+		r.index = make(map[string]int)
+	}
+	for i := from; i < len(r.entries); i++ {
+		r.index[r.entries[i].model.GetModelID()] = i
+	}
+}
+
+// setStop sets the function stopping the cancellation of the context of the
+// model identified by id along with the branch model one.
+func (r *Registry) setStop(id string, stop func() bool) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if i, ok := r.index[id]; ok {
+		r.entries[i].stop = stop
+	}
+}
+
+// hasDescendant returns whether the model identified by id is a descendant
+// of the branch model.
+func (r *Registry) hasDescendant(id string) bool {
+	if r == nil {
+		return false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.descendants[id]
+	return ok
+}
+
+// leadsTo returns whether a destination of the route, or a model holding one
+// of the released properties, is a descendant of the branch model.
+func (r *Registry) leadsTo(route Route) bool {
+	if r == nil {
+		return false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, id := range route.ModelIDs {
+		if _, ok := r.descendants[id]; ok {
+			return true
+		}
+	}
+	if route.Release != 0 {
+		for _, p := range r.held {
+			if p&route.Release != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// disablePanicked sets the Disabled property on the registered model
+// identified by id directly, without going through its Update() that may
+// panic on any message. The model is skipped by UpdateNodeModels until it is
+// enabled again. It returns whether the model was disabled.
+func (r *Registry) disablePanicked(id string) bool {
+	if r == nil {
+		return false
+	}
+	r.mu.Lock()
+	i, ok := r.index[id]
+	var model CommonModel
+	if ok {
+		model, ok = withProperties(r.entries[i].model, r.entries[i].model.GetProperties()|Disabled)
+		r.entries[i].model = model
+		r.entries[i].panicked = ok
+	}
+	r.mu.Unlock()
+
+	if ok {
+		r.track(map[string]Properties{id: model.GetProperties()})
+	}
+	return ok
+}
+
+// panicked returns whether the model identified by id was disabled after
+// panicking and wasn't enabled since.
+func (r *Registry) panicked(id string) bool {
+	if r == nil {
+		return false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if i, ok := r.index[id]; ok {
+		return r.entries[i].panicked
+	}
+	return false
+}
+
+// modal returns the ID of the descendant model capturing the console input,
+// the last one made modal, if any.
+func (r *Registry) modal() string {
+	if r == nil {
+		return ""
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if len(r.modals) == 0 {
+		return ""
+	}
+	return r.modals[len(r.modals)-1]
+}
+
+// adopt makes the registry the parent of the registry of model, if it is a
+// branch model, and returns the properties of model and its descendants, by
+// ID.
+func (r *Registry) adopt(model CommonModel) map[string]Properties {
+	subtree := map[string]Properties{model.GetModelID(): model.GetProperties()}
+	sub := registryOf(model)
+	if sub == nil || sub == r {
+		return subtree
+	}
+	sub.mu.Lock()
+	sub.parent = r
+	for id := range sub.descendants {
+		subtree[id] = sub.held[id]
+	}
+	sub.mu.Unlock()
+
+	if f := r.focusManager(); f != nil {
+		sub.shareFocus(f)
+	}
+	return subtree
+}
+
+// release unlinks the registry of model, if it is a branch model, from the
+// registry and returns the IDs of model and its descendants.
+func (r *Registry) release(model CommonModel) []string {
+	ids := []string{model.GetModelID()}
+	sub := registryOf(model)
+	if sub == nil || sub == r {
+		return ids
+	}
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+
+	if sub.parent == r {
+		sub.parent = nil
+	}
+	for id := range sub.descendants {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// track adds the descendant models properties, by ID, to the index and
+// reports the changes up to the parent registries.
+func (r *Registry) track(props map[string]Properties) {
+	for r != nil && len(props) > 0 {
+		r.mu.Lock()
+		if r.descendants == nil {
+			r.descendants = make(map[string]struct{})
+			r.held = make(map[string]Properties)
+		}
+		changed := make(map[string]Properties)
+		for id, p := range props {
+			_, known := r.descendants[id]
+			if known && r.held[id] == p {
+				continue
+			}
+			r.descendants[id] = struct{}{}
+			if p != 0 {
+				r.held[id] = p
+			} else {
+				delete(r.held, id)
+			}
+			if p&(Modal|Disabled|Hidden) == Modal {
+				if !slices.Contains(r.modals, id) {
+					r.modals = append(r.modals, id)
+				}
+			} else {
+				r.modals = slices.DeleteFunc(r.modals, func(m string) bool { return m == id })
+			}
+			changed[id] = p
+		}
+		parent := r.parent
+		r.mu.Unlock()
+
+		r, props = parent, changed
+	}
+}
+
+// untrack removes the descendant models IDs from the index and from the
+// parent registries ones.
+func (r *Registry) untrack(ids []string) {
+	for ; r != nil; r = r.parentRegistry() {
+		r.mu.Lock()
+		for _, id := range ids {
+			delete(r.descendants, id)
+			delete(r.held, id)
+		}
+		r.modals = slices.DeleteFunc(r.modals, func(m string) bool { return slices.Contains(ids, m) })
+		r.mu.Unlock()
+	}
+}
+
+// shareFocus links the registry, and the registries of the descendant branch
+// models, to the focus manager of the root model.
+func (r *Registry) shareFocus(f *FocusManager) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	r.focus = f
+	r.mu.Unlock()
+
+	r.Range(func(model CommonModel) bool {
+		if sub := registryOf(model); sub != r {
+			sub.shareFocus(f)
+		}
+		return true
+	})
+}
+
+// focusManager returns the focus manager the registry is linked to, if any.
+func (r *Registry) focusManager() *FocusManager {
+	if r == nil {
+		return nil
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.focus
+}
+
+// parentRegistry returns the registry of the parent branch model, if linked.
+func (r *Registry) parentRegistry() *Registry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.parent
+}
+
+// registryOf returns the registry of the branch model embedding
+// DefaultBranchModel, nil for other models.
+func registryOf(model CommonModel) *Registry {
+	if branch, ok := model.(interface{ registry() *Registry }); ok {
+		return branch.registry()
+	}
+	return nil
+}
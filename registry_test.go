@@ -0,0 +1,25 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import "testing"
+
+func TestNilRegistry(t *testing.T) {
+	var r *Registry
+	leaf := newTestLeaf(&msgLog{}, "pane-1")
+
+	r.Store(leaf)
+	r.StoreWithPriority(leaf, 1)
+	r.SetUpdateMode(SequentialUpdate)
+	if r.Delete("pane-1") {
+		t.Error("model deleted from a nil registry")
+	}
+	if _, ok := r.Get("pane-1"); ok || r.Len() != 0 || len(r.IDs()) != 0 {
+		t.Error("nil registry not empty")
+	}
+	if mode := r.UpdateMode(); mode != ConcurrentUpdate {
+		t.Errorf("update mode = %v, want %v", mode, ConcurrentUpdate)
+	}
+}
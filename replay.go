@@ -0,0 +1,188 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Replay feeds the messages recorded by a Recorder to a fresh model tree,
+// rooted at app, and returns its root model once done. The model IDs being
+// recorded, the tree should be built the same way as the recorded one, in a
+// new process.
+//
+// The tree commands are run as usual, but the messages of registered types
+// they return are dropped, their recorded copies being replayed instead, so
+// that the tree receives the recorded console input and async results. The
+// console input is dropped as well, except for ctrl+c which stops the
+// replay. Once done, the replay quits when headless or on the next key press.
+func Replay(app AppModel, r io.Reader, opts ...ReplayOption) (RootModel, error) {
+	recs, err := ReadRecording(r)
+	if err != nil {
+		return nil, err
+	}
+
+	rp := replayer{recs: recs, speed: 1}
+	for _, opt := range opts {
+		opt(&rp)
+	}
+	rp.root = New(app, rp.rootOpts...)
+	progOpts := rp.progOpts
+	if rp.headless {
+		progOpts = append(progOpts,
+			tea.WithInput(nil),
+			tea.WithOutput(io.Discard),
+			tea.WithoutRenderer(),
+			tea.WithoutSignalHandler())
+	}
+
+	final, err := tea.NewProgram(rp, progOpts...).Run()
+	if err != nil {
+		return nil, err
+	}
+	rp = final.(replayer)
+	return rp.root, rp.err
+}
+
+// ReplayOption is used to set options on the replay.
+type ReplayOption func(*replayer)
+
+// WithReplaySpeed sets the replay speed factor, e.g., 2 replays twice as
+// fast as recorded. Zero or less replays the messages without delay. The
+// default speed is 1, the recorded speed.
+func WithReplaySpeed(speed float64) ReplayOption {
+	return func(rp *replayer) {
+		rp.speed = speed
+	}
+}
+
+// WithHeadless replays without a terminal: no console input nor rendering,
+// the root model view being updated after each replayed message.
+func WithHeadless() ReplayOption {
+	return func(rp *replayer) {
+		rp.headless = true
+	}
+}
+
+// WithRootOptions passes options to the replayed root model, e.g.,
+// WithLevelController.
+func WithRootOptions(opts ...RootOption) ReplayOption {
+	return func(rp *replayer) {
+		rp.rootOpts = append(rp.rootOpts, opts...)
+	}
+}
+
+// WithProgramOptions passes options to the replay program, e.g.,
+// tea.WithAltScreen().
+func WithProgramOptions(opts ...tea.ProgramOption) ReplayOption {
+	return func(rp *replayer) {
+		rp.progOpts = append(rp.progOpts, opts...)
+	}
+}
+
+// replayer is the bubble tea model replaying the recorded messages to the
+// root model.
+type replayer struct {
+	root     RootModel
+	recs     []RecordedMsg
+	speed    float64
+	headless bool
+	rootOpts []RootOption
+	progOpts []tea.ProgramOption
+
+	// All of the messages were replayed.
+	done bool
+
+	// The error that stopped the replay.
+	err error
+}
+
+// Init implements tea.Model.
+func (rp replayer) Init() tea.Cmd {
+	return tea.Batch(rp.root.Init(), rp.replay(0))
+}
+
+// Update implements tea.Model.
+func (rp replayer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case replayMsg:
+		rec := rp.recs[msg.index]
+		if len(rec.Msg) == 0 {
+			return rp, rp.replay(msg.index + 1)
+		}
+		recorded, err := decodeMsg(rec.Type, rec.Msg)
+		if err != nil {
+			rp.err = fmt.Errorf("while replaying message %d: %w", msg.index+1, err)
+			return rp, tea.Quit
+		}
+		cmd := rp.update(recorded)
+		return rp, tea.Batch(cmd, rp.replay(msg.index+1))
+
+	case replayDoneMsg:
+		rp.done = true
+		if rp.headless {
+			return rp, tea.Quit
+		}
+		return rp, nil
+
+	case tea.KeyMsg:
+		if rp.done || msg.String() == "ctrl+c" {
+			return rp, tea.Quit
+		}
+		return rp, nil
+	}
+
+	if IsRecordable(msg) {
+		return rp, nil
+	}
+	return rp, rp.update(msg)
+}
+
+// update updates the root model, and its view when headless to keep track
+// of the model regions.
+func (rp *replayer) update(msg tea.Msg) tea.Cmd {
+	root, cmd := rp.root.Update(msg)
+	rp.root = root.(RootModel)
+	if rp.headless {
+		_ = rp.root.View()
+	}
+	return cmd
+}
+
+// View implements tea.Model.
+func (rp replayer) View() string {
+	return rp.root.View()
+}
+
+// replay returns the command replaying the message at index, after its
+// recorded delay scaled by the replay speed.
+func (rp replayer) replay(index int) tea.Cmd {
+	if index >= len(rp.recs) {
+		return func() tea.Msg { return replayDoneMsg{} }
+	}
+
+	var delay time.Duration
+	if index > 0 && rp.speed > 0 {
+		delay = time.Duration(float64(rp.recs[index].Time.Sub(rp.recs[index-1].Time)) / rp.speed)
+	}
+	if delay <= 0 {
+		return func() tea.Msg { return replayMsg{index: index} }
+	}
+	return tea.Tick(delay, func(time.Time) tea.Msg { return replayMsg{index: index} })
+}
+
+// Msg/Cmd's
+
+type (
+	// replayMsg triggers the replay of the recorded message at index.
+	replayMsg struct{ index int }
+
+	// replayDoneMsg is sent once all of the messages were replayed.
+	replayDoneMsg struct{}
+)
@@ -0,0 +1,63 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestReplayTaskProgress(t *testing.T) {
+	rec := recording(t, 100*time.Millisecond,
+		tea.WindowSizeMsg{Width: 80, Height: 24},
+		TaskProgressMsg{ModelID: "app-1", Progress: 0.5},
+		tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")},
+	)
+
+	log := &msgLog{}
+	app := newTestApp(log, "app-1")
+	app.init = func(m testApp) tea.Cmd {
+		return StartTask(m.DefaultCommonModel, "count", func(ctx context.Context, report TaskReporter) (int, error) {
+			report(0.5, "halfway")
+			// Return once the progress was received.
+			time.Sleep(50 * time.Millisecond)
+			return 42, nil
+		})
+	}
+	root, err := Replay(app, rec, WithHeadless())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := log.got("app-1")
+	if !slices.Contains(got, "bubbletree.TaskDoneMsg[int]") {
+		t.Errorf("app messages = %v, want a TaskDoneMsg", got)
+	}
+	if n := root.(DefaultRootModel).Width; n != 80 {
+		t.Errorf("root width = %d, want the recorded 80", n)
+	}
+}
+
+// recording returns the recording of msgs, received every interval.
+func recording(t *testing.T, interval time.Duration, msgs ...tea.Msg) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	start := time.Now()
+	for i, msg := range msgs {
+		rec := RecordedMsg{Time: start.Add(time.Duration(i) * interval)}
+		rec.Type, rec.Msg, _ = encodeMsg(msg)
+		if err := enc.Encode(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return &buf
+}
@@ -6,6 +6,8 @@ package bubbletree
 
 import (
 	"fmt"
+	"log/slog"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -32,9 +34,37 @@ type RootModel interface {
 }
 
 // New returns a new DefaultRootModel instance.
-func New(app AppModel) RootModel {
-	return &DefaultRootModel{
-		CoreApp: app,
+func New(app AppModel, opts ...RootOption) RootModel {
+	m := &DefaultRootModel{
+		CoreApp:  app,
+		Focus:    NewFocusManager(),
+		Help:     NewHelpOverlay(),
+		Overlays: NewOverlayStack(),
+		Notifier: NewNotifier(),
+		Mouse:    NewMouseTracker(),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	registryOf(app).shareFocus(m.Focus)
+	return m
+}
+
+// RootOption is used to set options on the root model.
+type RootOption func(*DefaultRootModel)
+
+// WithLevelController lets the root model change the log levels at runtime,
+// on SetLogLevelMsg and with the LogLevelKey binding.
+func WithLevelController(levels LevelController) RootOption {
+	return func(m *DefaultRootModel) {
+		m.Levels = levels
+	}
+}
+
+// WithRecorder records the messages received by the root model, see Replay.
+func WithRecorder(recorder *Recorder) RootOption {
+	return func(m *DefaultRootModel) {
+		m.Recorder = recorder
 	}
 }
 
@@ -46,6 +76,30 @@ type DefaultRootModel struct {
 	// The directly linked main application model.
 	CoreApp AppModel
 
+	// The focus path, focus stack and focus ring traversal manager.
+	Focus *FocusManager
+
+	// The built-in key bindings help overlay.
+	Help *HelpOverlay
+
+	// The overlays drawn over the core application view.
+	Overlays *OverlayStack
+
+	// The notification toasts drawn over all of the other views.
+	Notifier *Notifier
+
+	// The model regions of the last view and the mouse events routing.
+	Mouse *MouseTracker
+
+	// The optional recorder of the received messages.
+	Recorder *Recorder
+
+	// The optional controller of the log levels.
+	Levels LevelController
+
+	// Real-time screen dimensions (updated by tea.WindowSizeMsg).
+	Screen
+
 	// Ending program cleanup happened, bubble tea is quitting.
 	Quitting bool
 
@@ -60,7 +114,122 @@ func (m DefaultRootModel) Init() tea.Cmd {
 
 // Update is the default implementation of the RootModel interface.
 func (m DefaultRootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m.Recorder.record(msg)
+
+	if m.Focus == nil {
+		m.Focus = NewFocusManager()
+		registryOf(m.CoreApp).shareFocus(m.Focus)
+	}
+	if m.Help == nil {
+		m.Help = NewHelpOverlay()
+	}
+	if m.Overlays == nil {
+		m.Overlays = NewOverlayStack()
+	}
+	if m.Notifier == nil {
+		m.Notifier = NewNotifier()
+	}
+	if m.Mouse == nil {
+		m.Mouse = NewMouseTracker()
+	}
+
+	// Mouse events go to the overlay or the tree model under the cursor.
+	if mouse, ok := msg.(tea.MouseMsg); ok {
+		return m, m.routeMouse(mouse)
+	}
+
+	// Manage the overlay stack, the topmost modal overlay captures all of
+	// the console input.
 	switch msg := msg.(type) {
+	case PushOverlayMsg:
+		m.logger().Info("Pushing overlay", "ModelID", msg.Overlay.Model.GetModelID(), "Placement", msg.Overlay.Placement)
+		return m, m.Overlays.push(msg.Overlay, contextOf(m.CoreApp), m.theme(), m.Width, m.Height)
+	case PopOverlayMsg:
+		if m.Overlays.pop(msg.ModelID) {
+			m.logger().Info("Popped overlay", "ModelID", msg.ModelID)
+		}
+		return m, nil
+	}
+	if i := m.Overlays.modal(); i >= 0 && isInput(msg) {
+		return m, m.Overlays.update(i, msg)
+	}
+
+	// Display the notification toasts and expire them. Notifications are
+	// then passed down the tree, the displayed toasts action keys are
+	// consumed.
+	if tick, ok := msg.(noticeTickMsg); ok {
+		return m, m.Notifier.expire(time.Time(tick))
+	}
+	var expiry tea.Cmd
+	if notify, ok := msg.(NotifyMsg); ok {
+		notify.Notification, expiry = m.Notifier.push(notify.Notification)
+		m.logger().Info("Notification",
+			"ModelID", notify.Notification.SourceModelID,
+			"Level", notify.Notification.Level,
+			"Text", notify.Notification.Text)
+		msg = notify
+	}
+	if consumed, cmd := m.Notifier.action(msg); consumed {
+		return m, cmd
+	}
+
+	// Cycle the global log level, and apply the log level changes before
+	// passing them down the tree.
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.Levels != nil && Matches(keyMsg, LogLevelKey) {
+		return m, CycleLogLevelCmd(m.Levels)
+	}
+	var levelNotice tea.Cmd
+	if setLevel, ok := msg.(SetLogLevelMsg); ok && m.Levels != nil {
+		text := setLevel.apply(m.Levels)
+		m.logger().Info(text)
+		levelNotice = NotifyCmd(Notification{Level: InfoNotice, Text: text})
+	}
+
+	// Modal models capture all of the console input.
+	if isInput(msg) {
+		if id := m.modalID(); id != "" {
+			msg = Envelope{Route: Route{ModelIDs: []string{id}}, Msg: msg}
+		}
+	}
+
+	// Let the focus manager consume focus requests and traversal keys.
+	if consumed, cmd := m.Focus.Update(m.CoreApp, msg); consumed {
+		return m, cmd
+	}
+
+	// Let the help overlay consume the help keys, and all keys while shown.
+	if m.Help.Update(m.CoreApp, m.Focus.FocusedID(), msg) {
+		if m.Help.Visible() {
+			m.reportKeyConflicts()
+		}
+		return m, nil
+	}
+
+	// Task progress messages are followed by the next task message.
+	var next tea.Cmd
+	if progress, ok := msg.(TaskProgressMsg); ok {
+		next = progress.next
+	}
+
+	// Newly mounted models are sent the current screen size.
+	if mount, ok := msg.(MountMsg); ok {
+		mount.Screen = m.Screen
+		msg = mount
+	}
+
+	// Recoverable errors go to the nearest error boundary of their source
+	// model.
+	if errMsg, ok := msg.(ErrMsg); ok && errMsg.Severity == RecoverableSeverity && errMsg.SourceModelID != "" {
+		errMsg.BoundaryID = errorBoundary(m.CoreApp, errMsg.SourceModelID)
+		msg = errMsg
+	}
+
+	switch msg := msg.(type) {
+	// Keep track of the screen size to render root level views.
+	case tea.WindowSizeMsg:
+		m.Width = msg.Width
+		m.Height = msg.Height
+
 	// Check if the core application sends a program exit signal.
 	case ModelFinishedMsg:
 		if msg.IsRecipient(m.CoreApp.GetModelID()) {
@@ -68,24 +237,151 @@ func (m DefaultRootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 		}
 
-	// A model encountered an error, decode and treat the error
+	// A model encountered an error, decode and treat the error. Warnings are
+	// logged, recoverable errors go to the nearest error boundary, the
+	// others quit.
 	case ErrMsg:
-		m.Err = fmt.Errorf("error message received from model tree: %w", msg.Err)
-		m.Quitting = true
-		return m, tea.Quit
+		if msg.Severity == WarningSeverity {
+			m.logger().Warn("Model warning", "ModelID", msg.SourceModelID, "error", msg.Err)
+			return m, nil
+		}
+		if msg.Severity == FatalSeverity || msg.BoundaryID == "" {
+			m.Err = fmt.Errorf("error message received from model tree: %w", msg.Err)
+			m.Quitting = true
+			return m, tea.Quit
+		}
 	}
 
-	// Propagate current message to CoreApp's Update(msg).
-	branchModel, cmd := m.CoreApp.Update(msg)
-	m.CoreApp = branchModel.(AppModel)
+	// Propagate current message to CoreApp's Update(msg), unless addressed
+	// to models outside of the tree, like overlays.
+	routed, ok := routeTo(m.CoreApp, msg)
+	if !ok {
+		return m, tea.Batch(next, expiry, levelNotice, m.Overlays.broadcast(msg))
+	}
+	coreApp := m.CoreApp
+	var cmd tea.Cmd
+	m.CoreApp, cmd = m.updateCoreApp(routed)
+	// The core application has no parent to report its state changes to.
+	cmd = tea.Batch(cmd, next, expiry, levelNotice, stateChanged("", coreApp, m.CoreApp, routed))
+
+	// Overlays receive the same messages, except for console input.
+	if !isInput(msg) {
+		cmd = tea.Batch(cmd, m.Overlays.broadcast(msg))
+	}
+
+	// The focus path changed, check the newly active bindings.
+	if _, ok := msg.(SetFocusMsg); ok {
+		m.reportKeyConflicts()
+	}
 
 	// Return model tree gathered new commands from descendant models.
 	return m, cmd
 }
 
-// View is the default implementation of the RootModel interface.
-func (m DefaultRootModel) View() string {
-	return m.CoreApp.AppView(m.Quitting, m.Err)
+// updateCoreApp runs the core application Update(), recovering from panics
+// as fatal errors so that the program quits cleanly, restoring the terminal.
+func (m DefaultRootModel) updateCoreApp(msg tea.Msg) (app AppModel, cmd tea.Cmd) {
+	defer func() {
+		if v := recover(); v != nil {
+			e := newPanicError(m.CoreApp.GetModelID(), msg, v)
+			logPanic(modelLogger(m.CoreApp), e)
+			app, cmd = m.CoreApp, ErrCmd(e)
+		}
+	}()
+
+	branchModel, cmd := m.CoreApp.Update(msg)
+	return branchModel.(AppModel), cmd
+}
+
+// View is the default implementation of the RootModel interface. A panic in
+// the core application View() is recovered and its error rendered instead.
+func (m DefaultRootModel) View() (view string) {
+	defer m.Mouse.compose()()
+	defer func() {
+		if v := recover(); v != nil {
+			e := newPanicError(m.CoreApp.GetModelID(), nil, v)
+			logPanic(modelLogger(m.CoreApp), e)
+			view = e.Error()
+		}
+	}()
+
+	if m.Quitting {
+		return m.Mouse.record(m.CoreApp, m.CoreApp.AppView(m.Quitting, m.Err))
+	}
+	switch {
+	case m.Help != nil && m.Help.Visible():
+		view = m.Help.View(m.theme(), ActiveBindings(m.CoreApp, m.Focus.Path()), m.Width, m.Height)
+	case m.Overlays.Len() > 0:
+		view = m.Overlays.View(m.CoreApp.AppView(m.Quitting, m.Err), m.Width, m.Height)
+	default:
+		view = m.CoreApp.AppView(m.Quitting, m.Err)
+	}
+	if m.Notifier.Len() > 0 {
+		view = m.Notifier.View(m.theme(), view, m.Width, m.Height)
+	}
+	return m.Mouse.record(m.CoreApp, view)
+}
+
+// routeMouse delivers the mouse event to the topmost overlay under the
+// cursor, with translated coordinates, or to the deepest tree model under the
+// cursor. Events outside of a modal overlay are dropped.
+func (m DefaultRootModel) routeMouse(msg tea.MouseMsg) tea.Cmd {
+	if m.Help.Visible() {
+		return nil
+	}
+	if i := m.Overlays.at(msg.X, msg.Y, m.Width, m.Height); i >= 0 {
+		o, r := m.Overlays.overlays[i], m.Overlays.overlays[i].rect(m.Width, m.Height)
+		local := msg
+		local.X, local.Y = msg.X-r.X, msg.Y-r.Y
+		return tea.Batch(m.Mouse.hover(o.Model.GetModelID()), m.Overlays.update(i, local))
+	}
+	if m.Overlays.modal() >= 0 {
+		return m.Mouse.hover("")
+	}
+	return m.Mouse.route(m.CoreApp, m.modalID(), msg)
+}
+
+// reportKeyConflicts logs the conflicting active key bindings, if any and
+// when they changed since the last report.
+func (m DefaultRootModel) reportKeyConflicts() {
+	conflicts := KeyConflicts(ActiveBindings(m.CoreApp, m.Focus.Path()))
+	report := fmt.Sprint(conflicts)
+	if report == m.Help.reported {
+		return
+	}
+	m.Help.reported = report
+	for _, c := range conflicts {
+		m.logger().Warn("Conflicting key bindings", "Key", c.Key, "ModelIDs", c.ModelIDs)
+	}
+}
+
+// modalID returns the ID of the model capturing the console input, the last
+// descendant made modal or else the modal core application model, if any.
+func (m DefaultRootModel) modalID() string {
+	if id := registryOf(m.CoreApp).modal(); id != "" {
+		return id
+	}
+	if m.CoreApp.GetProperties()&(Modal|Disabled|Hidden) == Modal {
+		return m.CoreApp.GetModelID()
+	}
+	return ""
+}
+
+// logger returns the core application model logger, without its ModelID
+// attribute, when available.
+func (m DefaultRootModel) logger() *slog.Logger {
+	if l, ok := m.CoreApp.(interface{ unscopedLogger() *slog.Logger }); ok {
+		return l.unscopedLogger()
+	}
+	return slog.Default()
+}
+
+// theme returns the core application model theme when available.
+func (m DefaultRootModel) theme() Themer {
+	if t, ok := m.CoreApp.(interface{ GetTheme() Themer }); ok {
+		return t.GetTheme()
+	}
+	return DefaultMinimalTheme()
 }
 
 // LastError returns the last error recorded by the root model.
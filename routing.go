@@ -0,0 +1,124 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Route describes the destination of an addressed message. A message can be
+// addressed by a list of model IDs, wherever they are in the tree, or by a
+// tree path of model IDs leading from the core application model down to the
+// destination model. Branch models only forward addressed messages down the
+// subtrees leading to a destination, instead of broadcasting them to all of
+// their children.
+type Route struct {
+	// The destination model instances IDs.
+	ModelIDs []string
+
+	// The path of model IDs from the core application model to the
+	// destination model, e.g., ["coreapp-1", "tabs-1", "pane-3"]. When set,
+	// the last model of the path is the destination and ModelIDs is ignored.
+	Path []string
+
+	// Models holding any of these properties receive the message as well,
+	// even if not addressed. This lets messages like SetFocusMsg reach the
+	// model that needs to release the property.
+	Release Properties
+}
+
+// IsBroadcast returns whether the route has no destination, in which case
+// the message is delivered to all models.
+func (r Route) IsBroadcast() bool {
+	return len(r.ModelIDs) == 0 && len(r.Path) == 0
+}
+
+// IsRecipient returns whether the model instance is a destination of the
+// route.
+func (r Route) IsRecipient(id string) bool {
+	if len(r.Path) > 0 {
+		return r.Path[len(r.Path)-1] == id
+	}
+	return slices.Contains(r.ModelIDs, id)
+}
+
+// Addressed is implemented by messages that carry their own destination.
+// Those messages are delivered as-is to the models along the route.
+type Addressed interface {
+	MsgRoute() Route
+}
+
+// Envelope wraps an arbitrary message with a destination. Models on the way
+// to the destination receive the Envelope itself, which the default model
+// implementations ignore, while the destination models receive the wrapped
+// message. When a destination is a branch model, what it does with the
+// wrapped message, like forwarding it to its own children, is up to it.
+type Envelope struct {
+	Route
+
+	// The wrapped message.
+	Msg tea.Msg
+}
+
+// MsgRoute implements the Addressed interface.
+func (e Envelope) MsgRoute() Route {
+	return e.Route
+}
+
+// RouteCmd returns an Envelope delivering msg to the listed model instances
+// only.
+func RouteCmd(msg tea.Msg, ids ...string) tea.Cmd {
+	return func() tea.Msg {
+		return Envelope{Route: Route{ModelIDs: ids}, Msg: msg}
+	}
+}
+
+// RoutePathCmd returns an Envelope delivering msg along the tree path of
+// model IDs, to the last model of the path.
+func RoutePathCmd(msg tea.Msg, path ...string) tea.Cmd {
+	return func() tea.Msg {
+		return Envelope{Route: Route{Path: path}, Msg: msg}
+	}
+}
+
+// routeTo returns the message the model should receive for msg, and whether
+// the model should receive anything at all. Messages that aren't addressed
+// are broadcast to every model.
+func routeTo(model CommonModel, msg tea.Msg) (tea.Msg, bool) {
+	addressed, ok := msg.(Addressed)
+	if !ok {
+		return msg, true
+	}
+	route := addressed.MsgRoute()
+
+	// Destination models receive the wrapped message of envelopes.
+	if route.IsBroadcast() || route.IsRecipient(model.GetModelID()) ||
+		model.GetProperties()&route.Release != 0 {
+		if env, ok := msg.(Envelope); ok {
+			return env.Msg, true
+		}
+		return msg, true
+	}
+
+	// Models on the way to a destination receive the message as-is, to
+	// forward it to their own children.
+	if len(route.Path) > 0 {
+		return msg, slices.Contains(route.Path, model.GetModelID())
+	}
+	return msg, registryOf(model).leadsTo(route)
+}
+
+// isDescendant returns whether the model identified by id is a descendant of
+// model.
+func isDescendant(model CommonModel, id string) bool {
+	return registryOf(model).hasDescendant(id)
+}
+
+// modelRanger is implemented by branch models embedding DefaultBranchModel.
+type modelRanger interface {
+	RangeModels(fn func(model CommonModel) bool)
+}
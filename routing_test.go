@@ -0,0 +1,110 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// testTree returns the tree:
+//
+//	app-1
+//	├── tabs-1
+//	│   ├── pane-1
+//	│   └── pane-2
+//	└── status-1
+func testTree(log *msgLog) testApp {
+	tabs := newTestBranch(log, "tabs-1", newTestLeaf(log, "pane-1"), newTestLeaf(log, "pane-2"))
+	return newTestApp(log, "app-1", tabs, newTestLeaf(log, "status-1"))
+}
+
+func TestRouteTo(t *testing.T) {
+	log := &msgLog{}
+	app := testTree(log)
+	tabs := app.MustGetModel("tabs-1")
+	status := app.MustGetModel("status-1")
+
+	focused := newTestLeaf(log, "pane-2")
+	focused.Properties.SetFocused()
+	tabs.(testBranch).Models.Store(focused)
+
+	tests := []struct {
+		name  string
+		model CommonModel
+		msg   tea.Msg
+		want  bool
+	}{
+		{"broadcast", status, tea.WindowSizeMsg{}, true},
+		{"recipient", status, UnmountMsg{ModelID: "status-1"}, true},
+		{"on the way", tabs, UnmountMsg{ModelID: "pane-1"}, true},
+		{"off the way", status, UnmountMsg{ModelID: "pane-1"}, false},
+		{"unknown", tabs, UnmountMsg{ModelID: "pane-9"}, false},
+		{"released", tabs, SetFocusMsg{ModelID: "status-1"}, true},
+		{"not released", status, SetFocusMsg{ModelID: "pane-1"}, false},
+		{"path", tabs, Envelope{Route: Route{Path: []string{"app-1", "tabs-1", "pane-1"}}}, true},
+		{"off path", status, Envelope{Route: Route{Path: []string{"app-1", "tabs-1", "pane-1"}}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, got := routeTo(tt.model, tt.msg); got != tt.want {
+				t.Errorf("routeTo(%s, %T) = %v, want %v", tt.model.GetModelID(), tt.msg, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRouteToEnvelope(t *testing.T) {
+	log := &msgLog{}
+	app := testTree(log)
+	env := Envelope{Route: Route{ModelIDs: []string{"pane-1"}}, Msg: tea.FocusMsg{}}
+
+	if got, _ := routeTo(app.MustGetModel("tabs-1"), env); !isEnvelope(got) {
+		t.Errorf("routeTo(tabs-1) = %T, want the envelope", got)
+	}
+	tabs := app.MustGetModel("tabs-1").(testBranch)
+	if got, _ := routeTo(tabs.MustGetModel("pane-1"), env); isEnvelope(got) {
+		t.Errorf("routeTo(pane-1) = %T, want the wrapped message", got)
+	}
+}
+
+func isEnvelope(msg tea.Msg) bool {
+	_, ok := msg.(Envelope)
+	return ok
+}
+
+func TestRegistryDescendants(t *testing.T) {
+	log := &msgLog{}
+	app := testTree(log)
+	tabs := app.MustGetModel("tabs-1").(testBranch)
+
+	// Models linked deeper in the tree are indexed by their ancestors.
+	tabs.Models.Store(newTestBranch(log, "split-1", newTestLeaf(log, "pane-3")))
+	for _, id := range []string{"tabs-1", "pane-1", "split-1", "pane-3", "status-1"} {
+		if !app.Models.hasDescendant(id) {
+			t.Errorf("app descendants miss %s", id)
+		}
+	}
+
+	// Properties changes are reported up the tree.
+	pane := newTestLeaf(log, "pane-3")
+	pane.Properties.SetDisabled()
+	tabs.MustGetModel("split-1").(testBranch).Models.Store(pane)
+	if !app.Models.leadsTo(Route{Release: Disabled}) {
+		t.Error("app descendants don't hold Disabled")
+	}
+
+	// Deleted subtrees are removed from the ancestors indexes.
+	tabs.Models.Delete("split-1")
+	for _, id := range []string{"split-1", "pane-3"} {
+		if app.Models.hasDescendant(id) {
+			t.Errorf("app descendants still hold %s", id)
+		}
+	}
+	if app.Models.leadsTo(Route{Release: Disabled}) {
+		t.Error("app descendants still hold Disabled")
+	}
+}
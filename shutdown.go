@@ -0,0 +1,116 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// DefaultShutdownDeadline is the time a branch model waits for its
+	// descendants to finish their shutdown sequence, when no
+	// ShutdownDeadline is set. It is also the time leaf models wait for
+	// their tasks to return.
+	DefaultShutdownDeadline = 5 * time.Second
+)
+
+// shutdownDeadlineMsg is sent to a branch model when its shutdown deadline
+// expires.
+type shutdownDeadlineMsg struct{ ModelID string }
+
+// startShutdown enters the shutdown sequence of the branch model: its
+// context is cancelled and the shutdown request is forwarded to all of its
+// running children. The model only reports itself finished once all of them
+// have, or when the shutdown deadline expires.
+func (m *DefaultBranchModel) startShutdown(msg tea.Msg) tea.Cmd {
+	m.SetState(ShuttingDownState, msg)
+
+	m.CancelContext()
+
+	m.pending = nil
+	m.RangeModels(func(model CommonModel) bool {
+		if !model.IsFinished() {
+			m.pending = append(m.pending, model.GetModelID())
+		}
+		return true
+	})
+	if len(m.pending) == 0 {
+		m.LogAction(msg, "Requesting model finished")
+		return m.modelFinishedCmd(m.shutdownDeadline())
+	}
+
+	m.shutdownDone = make(chan struct{})
+	m.LogAction(msg, "Requesting descendants shutdown")
+	return tea.Batch(
+		ShutDownCmd(slices.Clone(m.pending)),
+		shutdownDeadlineCmd(m.GetModelID(), m.shutdownDeadline(), m.shutdownDone),
+	)
+}
+
+// childFinished records the end of a child shutdown sequence, and finishes
+// the branch model's own sequence when it was the last one running.
+func (m *DefaultBranchModel) childFinished(msg ModelFinishedMsg) tea.Cmd {
+	i := slices.Index(m.pending, msg.ModelID)
+	if i < 0 {
+		return nil
+	}
+	m.pending = slices.Delete(m.pending, i, i+1)
+	if len(m.pending) > 0 {
+		return nil
+	}
+	return m.finishShutdown(msg)
+}
+
+// shutdownExpired finishes the branch model shutdown sequence even though
+// some children are still running, logging the ones that overran the
+// deadline.
+func (m *DefaultBranchModel) shutdownExpired(msg shutdownDeadlineMsg) tea.Cmd {
+	for _, id := range m.pending {
+		m.ModelLogger().Warn("Shutdown deadline overrun",
+			"Descendant", id,
+			"Deadline", m.shutdownDeadline())
+	}
+	m.pending = nil
+	return m.finishShutdown(msg)
+}
+
+// finishShutdown releases the deadline timer and reports the branch model
+// finished.
+func (m *DefaultBranchModel) finishShutdown(msg tea.Msg) tea.Cmd {
+	if m.shutdownDone != nil {
+		close(m.shutdownDone)
+		m.shutdownDone = nil
+	}
+	m.LogAction(msg, "Requesting model finished")
+	return m.modelFinishedCmd(m.shutdownDeadline())
+}
+
+// shutdownDeadline returns the configured shutdown deadline or the default
+// one.
+func (m DefaultBranchModel) shutdownDeadline() time.Duration {
+	if m.ShutdownDeadline > 0 {
+		return m.ShutdownDeadline
+	}
+	return DefaultShutdownDeadline
+}
+
+// shutdownDeadlineCmd returns a shutdownDeadlineMsg after the deadline, or
+// nothing when the shutdown completes before.
+func shutdownDeadlineCmd(id string, deadline time.Duration, done <-chan struct{}) tea.Cmd {
+	return func() tea.Msg {
+		timer := time.NewTimer(deadline)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+			return shutdownDeadlineMsg{ModelID: id}
+		case <-done:
+			return nil
+		}
+	}
+}
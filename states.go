@@ -0,0 +1,172 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// The possible bubble tree model states
+const (
+	InactiveState State = iota
+	ActiveState
+	ShuttingDownState
+	FinishedState
+	SuspendedState
+	ErroredState
+)
+
+type State int
+
+func (s State) String() string {
+	switch s {
+	case InactiveState:
+		return "INACTIVE"
+	case ActiveState:
+		return "ACTIVE"
+	case ShuttingDownState:
+		return "SHUTTINGDOWN"
+	case FinishedState:
+		return "FINISHED"
+	case SuspendedState:
+		return "SUSPENDED"
+	case ErroredState:
+		return "ERRORED"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler, so that states are
+// readable in JSON tree dumps.
+func (s State) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, decoding the states
+// encoded by MarshalText.
+func (s *State) UnmarshalText(text []byte) error {
+	for state := InactiveState; state <= ErroredState; state++ {
+		if state.String() == string(text) {
+			*s = state
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown state %q", text)
+}
+
+// stateTransitions lists the states each state can move to. Any state but
+// Finished can enter the shutdown sequence, and Finished is final.
+var stateTransitions = map[State][]State{
+	InactiveState:     {ActiveState, SuspendedState, ErroredState, ShuttingDownState},
+	ActiveState:       {InactiveState, SuspendedState, ErroredState, ShuttingDownState},
+	SuspendedState:    {ActiveState, InactiveState, ErroredState, ShuttingDownState},
+	ErroredState:      {ActiveState, InactiveState, ShuttingDownState},
+	ShuttingDownState: {FinishedState},
+	FinishedState:     {},
+}
+
+// CanTransition returns whether a model in state s can move to the new
+// state.
+func (s State) CanTransition(state State) bool {
+	return slices.Contains(stateTransitions[s], state)
+}
+
+// StateEnterer is implemented by models that want to be called when they
+// enter a new state. OnEnter is called on the updated model by its parent,
+// with the previous state and the message that caused the transition. The
+// returned command is batched with the model's Update() one.
+type StateEnterer interface {
+	OnEnter(from State, msg tea.Msg) tea.Cmd
+}
+
+// StateExiter is implemented by models that want to be called when they
+// leave a state. OnExit is called on the updated model by its parent, before
+// OnEnter, with the new state and the message that caused the transition.
+type StateExiter interface {
+	OnExit(to State, msg tea.Msg) tea.Cmd
+}
+
+// SetState moves the model to the new state, logging the change. Invalid
+// transitions, like Finished to Active, are refused and logged as errors. It
+// returns whether the model is in the new state.
+func (m *DefaultCommonModel) SetState(state State, msg any) bool {
+	if m.State == state {
+		return true
+	}
+	if !m.State.CanTransition(state) {
+		m.log(1, slog.LevelError, "Invalid model state transition",
+			"State", m.State,
+			"NewState", state,
+			"OnMsg", fmt.Sprintf("%T", msg))
+		return false
+	}
+	m.State = state
+	m.logStateChange(1, msg)
+	return true
+}
+
+// stateChanged calls the state hooks of a model whose state changed while
+// handling msg, and reports the change to its parent, if any. Changes made by
+// assigning the State field directly bypass SetState validation, invalid
+// ones are logged and neither run the hooks nor get reported.
+func stateChanged(parentID string, old, new CommonModel, msg tea.Msg) tea.Cmd {
+	from, to := old.GetState(), new.GetState()
+	if from == to {
+		return nil
+	}
+	if !from.CanTransition(to) {
+		modelLogger(new).Error("Invalid model state transition",
+			"State", from,
+			"NewState", to,
+			"OnMsg", fmt.Sprintf("%T", msg))
+		return nil
+	}
+
+	var cmds []tea.Cmd
+	if exiter, ok := new.(StateExiter); ok {
+		cmds = append(cmds, exiter.OnExit(to, msg))
+	}
+	if enterer, ok := new.(StateEnterer); ok {
+		cmds = append(cmds, enterer.OnEnter(from, msg))
+	}
+	if parentID != "" {
+		cmds = append(cmds, StateChangedCmd(parentID, new.GetModelID(), from, to))
+	}
+	return tea.Sequence(cmds...)
+}
+
+// Msg/Cmd's
+
+// StateChangedMsg is an addressed message sent to the parent of a model
+// whose state changed, so that it can react.
+type StateChangedMsg struct {
+	ParentID string
+	ModelID  string
+	From, To State
+}
+
+// MsgRoute implements the Addressed interface.
+func (msg StateChangedMsg) MsgRoute() Route {
+	return Route{ModelIDs: []string{msg.ParentID}}
+}
+
+// IsRecipient returns whether the message is destined to the specified model
+// instance.
+func (msg StateChangedMsg) IsRecipient(id string) bool {
+	return msg.ParentID == id
+}
+
+// StateChangedCmd returns a message reporting the state change of a model to
+// its parent.
+func StateChangedCmd(parentID, id string, from, to State) tea.Cmd {
+	return func() tea.Msg {
+		return StateChangedMsg{ParentID: parentID, ModelID: id, From: from, To: to}
+	}
+}
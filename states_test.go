@@ -0,0 +1,74 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// hookedLeaf is a test leaf model recording its state hooks calls.
+type hookedLeaf struct {
+	testLeaf
+	hooks *[]string
+}
+
+func (m hookedLeaf) OnExit(to State, msg tea.Msg) tea.Cmd {
+	*m.hooks = append(*m.hooks, "exit to "+to.String())
+	return nil
+}
+
+func (m hookedLeaf) OnEnter(from State, msg tea.Msg) tea.Cmd {
+	*m.hooks = append(*m.hooks, "enter from "+from.String())
+	return nil
+}
+
+func TestStateChanged(t *testing.T) {
+	leaf := func(state State) testLeaf {
+		m := newTestLeaf(&msgLog{}, "pane-1")
+		m.State = state
+		return m
+	}
+
+	tests := []struct {
+		name     string
+		parentID string
+		old, new testLeaf
+		want     tea.Msg
+		hooks    []string
+	}{
+		{"unchanged", "tabs-1", leaf(InactiveState), leaf(InactiveState), nil, nil},
+		{
+			"reported", "tabs-1", leaf(InactiveState), leaf(ActiveState),
+			StateChangedMsg{ParentID: "tabs-1", ModelID: "pane-1", From: InactiveState, To: ActiveState},
+			[]string{"exit to ACTIVE", "enter from INACTIVE"},
+		},
+		{"no parent", "", leaf(InactiveState), leaf(ActiveState), nil, []string{"exit to ACTIVE", "enter from INACTIVE"}},
+		{"invalid", "tabs-1", leaf(FinishedState), leaf(ActiveState), nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hooks []string
+			cmd := stateChanged(tt.parentID, hookedLeaf{tt.old, &hooks}, hookedLeaf{tt.new, &hooks}, tea.KeyMsg{})
+			if !slices.Equal(hooks, tt.hooks) {
+				t.Errorf("hooks = %v, want %v", hooks, tt.hooks)
+			}
+			if tt.want == nil {
+				if cmd != nil {
+					t.Errorf("message = %v, want none", cmd())
+				}
+				return
+			}
+			if cmd == nil {
+				t.Fatalf("message = none, want %v", tt.want)
+			}
+			if got := cmd(); got != tt.want {
+				t.Errorf("message = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
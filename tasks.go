@@ -0,0 +1,336 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TaskFunc is a function run in the background by StartTask. It must return
+// when ctx, the owning model context, is cancelled. Progress is reported
+// with report, as a 0 to 1 completion ratio and a short status.
+type TaskFunc[T any] func(ctx context.Context, report TaskReporter) (T, error)
+
+// TaskReporter reports the progress of a running task. Reports are
+// coalesced: the owning model receives the latest one.
+type TaskReporter func(progress float64, status string)
+
+// TaskInfo describes a running task.
+type TaskInfo struct {
+	ID       int       `json:"id"`
+	Name     string    `json:"name"`
+	Progress float64   `json:"progress"`
+	Status   string    `json:"status,omitempty"`
+	Started  time.Time `json:"started"`
+}
+
+// TaskRunner keeps track of the background tasks of a model. A TaskRunner
+// is shared by pointer between the model copies and is safe for concurrent
+// use.
+type TaskRunner struct {
+	mu sync.Mutex
+
+	// The last task ID.
+	lastID int
+
+	// The running tasks, in start order.
+	tasks []*task
+
+	// Closed when the last running task returns.
+	idle chan struct{}
+}
+
+// task is a task started by a TaskRunner.
+type task struct {
+	mu   sync.Mutex
+	info TaskInfo
+
+	// Signals a new progress report.
+	updated chan struct{}
+
+	// Closed when the task returned, final is then its result message.
+	done  chan struct{}
+	final tea.Msg
+}
+
+// NewTaskRunner returns a new TaskRunner without tasks.
+func NewTaskRunner() *TaskRunner {
+	return &TaskRunner{}
+}
+
+// Len returns the number of running tasks.
+func (r *TaskRunner) Len() int {
+	if r == nil {
+		return 0
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.tasks)
+}
+
+// Tasks returns the description of the running tasks, in start order.
+func (r *TaskRunner) Tasks() []TaskInfo {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	infos := make([]TaskInfo, 0, len(r.tasks))
+	for _, t := range r.tasks {
+		t.mu.Lock()
+		infos = append(infos, t.info)
+		t.mu.Unlock()
+	}
+	return infos
+}
+
+// Wait blocks until all of the running tasks returned.
+func (r *TaskRunner) Wait() {
+	if idle := r.idleChan(); idle != nil {
+		<-idle
+	}
+}
+
+// WaitTimeout blocks until all of the running tasks returned, or until the
+// timeout expires. It returns whether the tasks all returned.
+func (r *TaskRunner) WaitTimeout(timeout time.Duration) bool {
+	idle := r.idleChan()
+	if idle == nil {
+		return true
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-idle:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+// idleChan returns the channel closed when the last running task returns,
+// nil when no task is running.
+func (r *TaskRunner) idleChan() <-chan struct{} {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.tasks) == 0 {
+		return nil
+	}
+	return r.idle
+}
+
+// add registers a new task.
+func (r *TaskRunner) add(name string) *task {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.tasks) == 0 {
+		r.idle = make(chan struct{})
+	}
+	r.lastID++
+	t := &task{
+		info:    TaskInfo{ID: r.lastID, Name: name, Started: time.Now()},
+		updated: make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+	r.tasks = append(r.tasks, t)
+	return t
+}
+
+// remove unregisters a returned task.
+func (r *TaskRunner) remove(t *task) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tasks = slices.DeleteFunc(r.tasks, func(rt *task) bool { return rt == t })
+	if len(r.tasks) == 0 {
+		close(r.idle)
+	}
+}
+
+// report records the task progress and signals it.
+func (t *task) report(progress float64, status string) {
+	t.mu.Lock()
+	t.info.Progress = min(max(progress, 0), 1)
+	t.info.Status = status
+	t.mu.Unlock()
+
+	select {
+	case t.updated <- struct{}{}:
+	default:
+	}
+}
+
+// next waits for the task progress or result message.
+func (t *task) next(modelID string) tea.Msg {
+	select {
+	case <-t.done:
+		return t.final
+	case <-t.updated:
+	}
+	select {
+	case <-t.done:
+		return t.final
+	default:
+	}
+
+	t.mu.Lock()
+	info := t.info
+	t.mu.Unlock()
+	return TaskProgressMsg{
+		ModelID:  modelID,
+		TaskID:   info.ID,
+		Name:     info.Name,
+		Progress: info.Progress,
+		Status:   info.Status,
+		next:     func() tea.Msg { return t.next(modelID) },
+	}
+}
+
+// StartTask runs fn in the background under the model context, usually from
+// the model's Update(). The model is sent TaskProgressMsg messages as the
+// task reports its progress, and a TaskDoneMsg or a TaskErrorMsg once it
+// returns. The model's Tasks runner must be set, a recoverable error of the
+// model is reported otherwise. The model shutdown sequence waits for the task
+// to return before reporting the model finished, up to the shutdown deadline.
+func StartTask[T any](model DefaultCommonModel, name string, fn TaskFunc[T]) tea.Cmd {
+	id := model.GetModelID()
+	if model.Tasks == nil {
+		return ModelErrCmd(id, RecoverableSeverity, fmt.Errorf("cannot start task %q of model %s: no task runner", name, id))
+	}
+	ctx := model.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	t := model.Tasks.add(name)
+	go func() {
+		defer model.Tasks.remove(t)
+		defer close(t.done)
+
+		result, err := fn(ctx, t.report)
+		switch {
+		case errors.Is(err, context.Canceled) && context.Cause(ctx) != nil:
+			t.final = TaskErrorMsg{ModelID: id, TaskID: t.info.ID, Name: name, Err: fmt.Errorf("%w: %w", err, context.Cause(ctx))}
+		case err != nil:
+			t.final = TaskErrorMsg{ModelID: id, TaskID: t.info.ID, Name: name, Err: err}
+		default:
+			t.final = TaskDoneMsg[T]{ModelID: id, TaskID: t.info.ID, Name: name, Result: result}
+		}
+	}()
+	return func() tea.Msg {
+		return t.next(id)
+	}
+}
+
+// runningTasks returns the model running tasks, for the tree introspection.
+func (m DefaultCommonModel) runningTasks() []TaskInfo {
+	return m.Tasks.Tasks()
+}
+
+// modelFinishedCmd returns the ModelFinishedMsg of the model, once all of its
+// tasks returned or when the deadline expires. The tasks still running past
+// the deadline are logged.
+func (m DefaultCommonModel) modelFinishedCmd(deadline time.Duration) tea.Cmd {
+	if m.Tasks.Len() == 0 {
+		return ModelFinishedCmd(m.GetModelID())
+	}
+	tasks, id, logger := m.Tasks, m.GetModelID(), m.ModelLogger()
+	return func() tea.Msg {
+		if !tasks.WaitTimeout(deadline) {
+			for _, info := range tasks.Tasks() {
+				logger.Warn("Shutdown deadline overrun",
+					"Task", info.Name,
+					"TaskID", info.ID,
+					"Deadline", deadline)
+			}
+		}
+		return ModelFinishedMsg{ModelID: id}
+	}
+}
+
+// Msg/Cmd's
+
+type (
+	// TaskProgressMsg is an addressed message reporting the progress of a
+	// task to its owning model.
+	TaskProgressMsg struct {
+		ModelID  string
+		TaskID   int
+		Name     string
+		Progress float64
+		Status   string
+
+		// Waits for the next task message, run by the root model.
+		next tea.Cmd
+	}
+
+	// TaskDoneMsg is an addressed message delivering the result of a task to
+	// its owning model.
+	TaskDoneMsg[T any] struct {
+		ModelID string
+		TaskID  int
+		Name    string
+		Result  T
+	}
+
+	// TaskErrorMsg is an addressed message reporting the failure of a task
+	// to its owning model.
+	TaskErrorMsg struct {
+		ModelID string
+		TaskID  int
+		Name    string
+		Err     error
+	}
+)
+
+// MsgRoute implements the Addressed interface.
+func (msg TaskProgressMsg) MsgRoute() Route {
+	return Route{ModelIDs: []string{msg.ModelID}}
+}
+
+// IsRecipient returns whether the message is destined to the specified model
+// instance.
+func (msg TaskProgressMsg) IsRecipient(id string) bool {
+	return msg.ModelID == id
+}
+
+// MsgRoute implements the Addressed interface.
+func (msg TaskDoneMsg[T]) MsgRoute() Route {
+	return Route{ModelIDs: []string{msg.ModelID}}
+}
+
+// IsRecipient returns whether the message is destined to the specified model
+// instance.
+func (msg TaskDoneMsg[T]) IsRecipient(id string) bool {
+	return msg.ModelID == id
+}
+
+// MsgRoute implements the Addressed interface.
+func (msg TaskErrorMsg) MsgRoute() Route {
+	return Route{ModelIDs: []string{msg.ModelID}}
+}
+
+// IsRecipient returns whether the message is destined to the specified model
+// instance.
+func (msg TaskErrorMsg) IsRecipient(id string) bool {
+	return msg.ModelID == id
+}
+
+func (msg TaskErrorMsg) Error() string {
+	return fmt.Sprintf("task %q of model %s: %v", msg.Name, msg.ModelID, msg.Err)
+}
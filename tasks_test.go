@@ -0,0 +1,61 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestModelFinishedCmdDeadline(t *testing.T) {
+	m := testCommon("stuck-1")
+	release := make(chan struct{})
+	defer close(release)
+
+	// The task ignores its context.
+	StartTask(m, "stuck", func(ctx context.Context, report TaskReporter) (int, error) {
+		<-release
+		return 0, nil
+	})
+	m.CancelContext()
+
+	start := time.Now()
+	msg := m.modelFinishedCmd(50 * time.Millisecond)()
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("modelFinishedCmd waited %v past its deadline", elapsed)
+	}
+	if finished, ok := msg.(ModelFinishedMsg); !ok || finished.ModelID != "stuck-1" {
+		t.Errorf("modelFinishedCmd() = %#v, want the model finished", msg)
+	}
+	if n := m.Tasks.Len(); n != 1 {
+		t.Errorf("running tasks = %d, want the stuck one", n)
+	}
+}
+
+func TestTaskRunnerWaitTimeout(t *testing.T) {
+	m := testCommon("task-1")
+	if !m.Tasks.WaitTimeout(0) {
+		t.Error("WaitTimeout() = false without tasks")
+	}
+	StartTask(m, "quick", func(ctx context.Context, report TaskReporter) (int, error) {
+		return 1, nil
+	})
+	if !m.Tasks.WaitTimeout(time.Second) {
+		t.Error("WaitTimeout() = false, want the task returned")
+	}
+}
+
+func TestStartTaskNoRunner(t *testing.T) {
+	m := testCommon("task-1")
+	m.Tasks = nil
+
+	msg := StartTask(m, "orphan", func(context.Context, TaskReporter) (int, error) {
+		return 0, nil
+	})()
+	if e, ok := msg.(ErrMsg); !ok || e.Severity != RecoverableSeverity || e.SourceModelID != "task-1" {
+		t.Errorf("StartTask() = %#v, want a recoverable error of the model", msg)
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2026 Yannick Cote <yhcote@gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package bubbletree
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// NodeInfo describes a model instance visited while walking the model tree.
+type NodeInfo struct {
+	ID         string     `json:"id"`
+	ParentID   string     `json:"parentId,omitempty"`
+	Depth      int        `json:"depth"`
+	Type       string     `json:"type"`
+	State      State      `json:"state"`
+	Properties Properties `json:"properties"`
+	ChildCount int        `json:"childCount"`
+	Tasks      []TaskInfo `json:"tasks,omitempty"`
+}
+
+// WalkFunc is called for each model visited by Walk. Returning false stops
+// the walk.
+type WalkFunc func(model CommonModel, info NodeInfo) bool
+
+// Walk visits model and all of its descendants, depth first, children in
+// registry order. Descendants are found through the branch models embedding
+// DefaultBranchModel.
+func Walk(model CommonModel, fn WalkFunc) {
+	walk(model, "", 0, fn)
+}
+
+// walk visits model at depth under parentID, returning false when the walk
+// was stopped.
+func walk(model CommonModel, parentID string, depth int, fn WalkFunc) bool {
+	info := NodeInfo{
+		ID:         model.GetModelID(),
+		ParentID:   parentID,
+		Depth:      depth,
+		Type:       fmt.Sprintf("%T", model),
+		State:      model.GetState(),
+		Properties: model.GetProperties(),
+	}
+	if tasks, ok := model.(interface{ runningTasks() []TaskInfo }); ok {
+		info.Tasks = tasks.runningTasks()
+	}
+	ranger, isBranch := model.(modelRanger)
+	if isBranch {
+		ranger.RangeModels(func(CommonModel) bool {
+			info.ChildCount++
+			return true
+		})
+	}
+	if !fn(model, info) {
+		return false
+	}
+
+	cont := true
+	if isBranch {
+		ranger.RangeModels(func(child CommonModel) bool {
+			cont = walk(child, info.ID, depth+1, fn)
+			return cont
+		})
+	}
+	return cont
+}
+
+// Find returns the model identified by id in the tree under model, along
+// with its description.
+func Find(model CommonModel, id string) (found CommonModel, info NodeInfo, ok bool) {
+	Walk(model, func(m CommonModel, i NodeInfo) bool {
+		if i.ID == id {
+			found, info, ok = m, i, true
+		}
+		return !ok
+	})
+	return found, info, ok
+}
+
+// FindAll returns the descriptions of the models matching in the tree under
+// model, in walk order.
+func FindAll(model CommonModel, match WalkFunc) []NodeInfo {
+	var infos []NodeInfo
+	Walk(model, func(m CommonModel, i NodeInfo) bool {
+		if match(m, i) {
+			infos = append(infos, i)
+		}
+		return true
+	})
+	return infos
+}
+
+// TreeNode is a model tree snapshot node, as dumped by DumpJSON.
+type TreeNode struct {
+	NodeInfo
+	Children []*TreeNode `json:"children,omitempty"`
+}
+
+// Snapshot returns the model tree under model as nested nodes.
+func Snapshot(model CommonModel) *TreeNode {
+	var (
+		root  *TreeNode
+		stack []*TreeNode
+	)
+	Walk(model, func(_ CommonModel, info NodeInfo) bool {
+		node := &TreeNode{NodeInfo: info}
+		stack = stack[:info.Depth]
+		if info.Depth == 0 {
+			root = node
+		} else {
+			parent := stack[info.Depth-1]
+			parent.Children = append(parent.Children, node)
+		}
+		stack = append(stack, node)
+		return true
+	})
+	return root
+}
+
+// DumpText renders the model tree under model as indented text, one model
+// per line, e.g.:
+//
+//	coreapp-1 (coreapp.Model) ACTIVE FOCUSED
+//	└── configurator-1 (*configurator.Model) INACTIVE NONE
+func DumpText(model CommonModel) string {
+	var b strings.Builder
+	dumpText(&b, Snapshot(model), "", "")
+	return b.String()
+}
+
+// dumpText writes the node line and its children with the tree drawing
+// prefixes.
+func dumpText(b *strings.Builder, node *TreeNode, prefix, childPrefix string) {
+	fmt.Fprintf(b, "%s%s (%s) %v %v\n", prefix, node.ID, node.Type, node.State, node.Properties)
+	taskPrefix := childPrefix + "    "
+	if len(node.Children) > 0 {
+		taskPrefix = childPrefix + "│   "
+	}
+	for _, t := range node.Tasks {
+		fmt.Fprintf(b, "%s[task %d] %s %.0f%% %s\n", taskPrefix, t.ID, t.Name, t.Progress*100, t.Status)
+	}
+	for i, child := range node.Children {
+		if i == len(node.Children)-1 {
+			dumpText(b, child, childPrefix+"└── ", childPrefix+"    ")
+		} else {
+			dumpText(b, child, childPrefix+"├── ", childPrefix+"│   ")
+		}
+	}
+}
+
+// DumpJSON renders the model tree under model as indented JSON.
+func DumpJSON(model CommonModel) ([]byte, error) {
+	return json.MarshalIndent(Snapshot(model), "", "  ")
+}